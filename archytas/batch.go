@@ -0,0 +1,143 @@
+package archytas
+
+import (
+	"errors"
+	"time"
+
+	"github.com/dgraph-io/badger/v3"
+)
+
+// BatchResult reports per-key outcomes of a batch operation. Values is only filled by
+// ReadMany; a key present in Errors failed, every other requested key succeeded.
+type BatchResult struct {
+	Values map[string][]byte
+	Errors map[string]error
+}
+
+func newBatchResult() *BatchResult {
+	return &BatchResult{
+		Values: make(map[string][]byte),
+		Errors: make(map[string]error),
+	}
+}
+
+func (r *BatchResult) Failed() []string {
+	keys := make([]string, 0, len(r.Errors))
+	for key := range r.Errors {
+		keys = append(keys, key)
+	}
+
+	return keys
+}
+
+func (r *BatchResult) Err() error {
+	if len(r.Errors) == 0 {
+		return nil
+	}
+
+	errs := make([]error, 0, len(r.Errors))
+	for _, err := range r.Errors {
+		errs = append(errs, err)
+	}
+
+	return errors.Join(errs...)
+}
+
+type batchOp struct {
+	key   string
+	apply func(txn *badger.Txn) error
+}
+
+func (b *Badger) SetMany(items map[string]string, ttl time.Duration) (*BatchResult, error) {
+	ops := make([]batchOp, 0, len(items))
+	for key, value := range items {
+		e := badger.NewEntry([]byte(key), []byte(value))
+		if ttl > 0 {
+			e = e.WithTTL(ttl)
+		}
+		ops = append(ops, batchOp{key: key, apply: func(txn *badger.Txn) error {
+			return txn.SetEntry(e)
+		}})
+	}
+
+	return b.writeMany(ops)
+}
+
+func (b *Badger) DeleteMany(keys []string) (*BatchResult, error) {
+	ops := make([]batchOp, 0, len(keys))
+	for _, key := range keys {
+		k := []byte(key)
+		ops = append(ops, batchOp{key: key, apply: func(txn *badger.Txn) error {
+			return txn.Delete(k)
+		}})
+	}
+
+	return b.writeMany(ops)
+}
+
+func (b *Badger) ReadMany(keys []string) (*BatchResult, error) {
+	result := newBatchResult()
+	err := b.db.View(func(txn *badger.Txn) error {
+		for _, key := range keys {
+			item, err := txn.Get([]byte(key))
+			if err != nil {
+				result.Errors[key] = b.countMiss(key, err)
+				continue
+			}
+
+			value, err := item.ValueCopy(nil)
+			if err != nil {
+				result.Errors[key] = err
+				continue
+			}
+			result.Values[key] = value
+			b.hits.Add(1)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// writeMany applies all ops in as few transactions as possible: when a transaction
+// grows past badger's limit (ErrTxnTooBig) it is committed and a fresh one is started.
+// A failed commit marks every key written in that transaction as failed.
+func (b *Badger) writeMany(ops []batchOp) (*BatchResult, error) {
+	result := newBatchResult()
+	txn := b.db.NewTransaction(true)
+	pending := make([]string, 0, len(ops))
+
+	commit := func() {
+		if err := txn.Commit(); err != nil {
+			for _, key := range pending {
+				result.Errors[key] = err
+			}
+		}
+		pending = pending[:0]
+	}
+
+	for _, op := range ops {
+		err := op.apply(txn)
+		if errors.Is(err, badger.ErrTxnTooBig) {
+			commit()
+			txn = b.db.NewTransaction(true)
+			err = op.apply(txn)
+		}
+		if errors.Is(err, badger.ErrDBClosed) {
+			txn.Discard()
+			return nil, err
+		}
+		if err != nil {
+			result.Errors[op.key] = err
+			continue
+		}
+
+		pending = append(pending, op.key)
+	}
+
+	commit()
+	return result, nil
+}
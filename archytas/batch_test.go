@@ -0,0 +1,81 @@
+package archytas
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBadgerBatch(t *testing.T) {
+	t.Run("SetManyAndReadMany", func(t *testing.T) {
+		testClient, err := NewInMemoryBadgerClient()
+		assert.Nil(t, err)
+		defer testClient.Close()
+
+		items := map[string]string{"λόγος": "word", "ἀνήρ": "man", "θεός": "god"}
+		result, err := testClient.SetMany(items, 0)
+		assert.Nil(t, err)
+		assert.Nil(t, result.Err())
+
+		sut, err := testClient.ReadMany([]string{"λόγος", "ἀνήρ", "θεός", "missing"})
+		assert.Nil(t, err)
+		assert.Equal(t, 3, len(sut.Values))
+		assert.Equal(t, "man", string(sut.Values["ἀνήρ"]))
+		assert.Equal(t, []string{"missing"}, sut.Failed())
+		assert.True(t, errors.Is(sut.Errors["missing"], ErrNotFound))
+	})
+
+	t.Run("SetManyWithTTL", func(t *testing.T) {
+		testClient, err := NewInMemoryBadgerClient()
+		assert.Nil(t, err)
+		defer testClient.Close()
+
+		_, err = testClient.SetMany(map[string]string{"key": "value"}, time.Hour)
+		assert.Nil(t, err)
+
+		_, ttl, err := testClient.ReadWithTTL("key")
+		assert.Nil(t, err)
+		assert.True(t, ttl > 0)
+	})
+
+	t.Run("DeleteMany", func(t *testing.T) {
+		testClient, err := NewInMemoryBadgerClient()
+		assert.Nil(t, err)
+		defer testClient.Close()
+
+		_, err = testClient.SetMany(map[string]string{"a": "1", "b": "2", "c": "3"}, 0)
+		assert.Nil(t, err)
+
+		result, err := testClient.DeleteMany([]string{"a", "b"})
+		assert.Nil(t, err)
+		assert.Nil(t, result.Err())
+
+		sut, err := testClient.ReadMany([]string{"a", "b", "c"})
+		assert.Nil(t, err)
+		assert.Equal(t, map[string][]byte{"c": []byte("3")}, sut.Values)
+		assert.Equal(t, 2, len(sut.Errors))
+	})
+
+	t.Run("SetManySplitsLargeBatches", func(t *testing.T) {
+		testClient, err := NewInMemoryBadgerClient()
+		assert.Nil(t, err)
+		defer testClient.Close()
+
+		items := make(map[string]string)
+		value := string(make([]byte, 1024))
+		for i := 0; i < 50000; i++ {
+			items[fmt.Sprintf("key-%d", i)] = value
+		}
+
+		result, err := testClient.SetMany(items, 0)
+		assert.Nil(t, err)
+		assert.Nil(t, result.Err())
+
+		exists, err := testClient.Exists("key-49999")
+		assert.Nil(t, err)
+		assert.True(t, exists)
+	})
+}
@@ -1,12 +1,28 @@
 package archytas
 
 import (
-	"github.com/dgraph-io/badger/v3"
+	"errors"
+	"fmt"
+	"os"
 	"time"
+
+	"github.com/dgraph-io/badger/v3"
 )
 
+// Close stops the background garbage collection, closes the database and, when
+// DeleteOnClose is set, removes its directory. Calling Close more than once is safe.
 func (b *Badger) Close() error {
-	return b.db.Close()
+	b.closeOnce.Do(func() {
+		close(b.stop)
+		<-b.done
+
+		b.closeErr = b.db.Close()
+		if b.options.DeleteOnClose && b.options.Path != "" {
+			b.closeErr = errors.Join(b.closeErr, os.RemoveAll(b.options.Path))
+		}
+	})
+
+	return b.closeErr
 }
 
 func (b *Badger) Set(key, value string) error {
@@ -26,6 +42,10 @@ func (b *Badger) Set(key, value string) error {
 }
 
 func (b *Badger) SetWithTTL(key, value string, ttl time.Duration) error {
+	if ttl <= 0 {
+		return b.Set(key, value)
+	}
+
 	txn := b.db.NewTransaction(true)
 	defer txn.Discard()
 
@@ -43,19 +63,65 @@ func (b *Badger) SetWithTTL(key, value string, ttl time.Duration) error {
 }
 
 func (b *Badger) Read(key string) ([]byte, error) {
+	value, _, err := b.ReadWithTTL(key)
+	return value, err
+}
+
+func (b *Badger) ReadWithTTL(key string) ([]byte, time.Duration, error) {
 	var copiedValue []byte
+	var ttl time.Duration
 	err := b.db.View(func(txn *badger.Txn) error {
 		item, err := txn.Get([]byte(key))
 		if err != nil {
 			return err
 		}
 
+		if expiresAt := item.ExpiresAt(); expiresAt > 0 {
+			ttl = time.Until(time.Unix(int64(expiresAt), 0))
+		}
+
 		err = item.Value(func(val []byte) error {
 			copiedValue = append([]byte{}, val...)
 			return nil
 		})
 		return err
 	})
+	if err != nil {
+		return nil, 0, b.countMiss(key, err)
+	}
+
+	b.hits.Add(1)
+	return copiedValue, ttl, nil
+}
+
+func (b *Badger) Exists(key string) (bool, error) {
+	err := b.db.View(func(txn *badger.Txn) error {
+		_, err := txn.Get([]byte(key))
+		return err
+	})
+	if errors.Is(err, badger.ErrKeyNotFound) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+func (b *Badger) countMiss(key string, err error) error {
+	err = wrapNotFound(key, err)
+	if errors.Is(err, ErrNotFound) {
+		b.misses.Add(1)
+	}
+
+	return err
+}
+
+func wrapNotFound(key string, err error) error {
+	if errors.Is(err, badger.ErrKeyNotFound) {
+		return fmt.Errorf("%w: %q", ErrNotFound, key)
+	}
 
-	return copiedValue, err
+	return err
 }
@@ -1,6 +1,7 @@
 package archytas
 
 import (
+	"errors"
 	uuid2 "github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
 	"path/filepath"
@@ -68,6 +69,66 @@ func TestBadgerClient(t *testing.T) {
 		testClient.Close()
 	})
 
+	t.Run("ReadMissIsErrNotFound", func(t *testing.T) {
+		testClient, err := NewInMemoryBadgerClient()
+		assert.Nil(t, err)
+
+		sut, err := testClient.Read(key)
+		assert.True(t, errors.Is(err, ErrNotFound))
+		assert.Nil(t, sut)
+
+		exists, err := testClient.Exists(key)
+		assert.Nil(t, err)
+		assert.False(t, exists)
+		testClient.Close()
+	})
+
+	t.Run("ReadWithTTL", func(t *testing.T) {
+		testClient, err := NewInMemoryBadgerClient()
+		assert.Nil(t, err)
+
+		err = testClient.SetWithTTL(key, value, time.Hour)
+		assert.Nil(t, err)
+
+		sut, ttl, err := testClient.ReadWithTTL(key)
+		assert.Nil(t, err)
+		assert.Equal(t, value, string(sut))
+		assert.True(t, ttl > 59*time.Minute)
+		assert.True(t, ttl <= time.Hour)
+
+		exists, err := testClient.Exists(key)
+		assert.Nil(t, err)
+		assert.True(t, exists)
+		testClient.Close()
+	})
+
+	t.Run("ReadWithTTLWithoutExpiry", func(t *testing.T) {
+		testClient, err := NewInMemoryBadgerClient()
+		assert.Nil(t, err)
+
+		err = testClient.Set(key, value)
+		assert.Nil(t, err)
+
+		sut, ttl, err := testClient.ReadWithTTL(key)
+		assert.Nil(t, err)
+		assert.Equal(t, value, string(sut))
+		assert.Equal(t, time.Duration(0), ttl)
+		testClient.Close()
+	})
+
+	t.Run("ExpiredKeyIsErrNotFound", func(t *testing.T) {
+		testClient, err := NewInMemoryBadgerClient()
+		assert.Nil(t, err)
+
+		err = testClient.SetWithTTL(key, value, time.Millisecond)
+		assert.Nil(t, err)
+
+		time.Sleep(time.Second)
+		_, _, err = testClient.ReadWithTTL(key)
+		assert.True(t, errors.Is(err, ErrNotFound))
+		testClient.Close()
+	})
+
 	t.Run("AbilityToOpenTwoDatabases", func(t *testing.T) {
 		uuid := uuid2.New().String()
 		badgerPath := filepath.Join("/tmp", "badger", uuid)
@@ -89,3 +150,35 @@ func TestBadgerClient(t *testing.T) {
 		newTestClient.Close()
 	})
 }
+
+func TestZeroTTLNeverExpires(t *testing.T) {
+	badgerClient, err := NewInMemoryBadgerClient()
+	assert.Nil(t, err)
+	defer badgerClient.Close()
+	memoryClient, err := NewMemoryClient(MemoryOptions{})
+	assert.Nil(t, err)
+	tieredL2, err := NewInMemoryBadgerClient()
+	assert.Nil(t, err)
+	tieredClient, err := NewTieredClient(tieredL2, MemoryOptions{})
+	assert.Nil(t, err)
+	defer tieredClient.Close()
+
+	backends := map[string]Client{
+		"Badger":    badgerClient,
+		"Memory":    memoryClient,
+		"Tiered":    tieredClient,
+		"Namespace": badgerClient.Namespace("words"),
+	}
+	for name, testClient := range backends {
+		t.Run(name, func(t *testing.T) {
+			for _, ttl := range []time.Duration{0, -time.Second} {
+				assert.Nil(t, testClient.SetWithTTL("λόγος", "word", ttl))
+
+				sut, remaining, err := testClient.ReadWithTTL("λόγος")
+				assert.Nil(t, err)
+				assert.Equal(t, "word", string(sut))
+				assert.Equal(t, time.Duration(0), remaining)
+			}
+		})
+	}
+}
@@ -1,53 +1,67 @@
 package archytas
 
 import (
+	"errors"
 	"github.com/dgraph-io/badger/v3"
-	uuid2 "github.com/google/uuid"
-	"path/filepath"
+	"io"
+	"sync"
+	"sync/atomic"
 	"time"
 )
 
+// ErrNotFound is returned (wrapped) by every read when the key is absent or expired,
+// so callers can tell a cache miss apart from a storage failure with errors.Is.
+var ErrNotFound = errors.New("archytas: key not found")
+
 type Client interface {
 	Close() error
 	Set(key, value string) error
+	// SetWithTTL stores value until ttl has passed. A ttl of zero or less never
+	// expires, the same as Set and SetMany.
 	SetWithTTL(key, value string, ttl time.Duration) error
 	Read(key string) ([]byte, error)
+	ReadWithTTL(key string) ([]byte, time.Duration, error)
+	Exists(key string) (bool, error)
+	SetMany(items map[string]string, ttl time.Duration) (*BatchResult, error)
+	ReadMany(keys []string) (*BatchResult, error)
+	DeleteMany(keys []string) (*BatchResult, error)
+	Keys(prefix string) ([]string, error)
+	Scan(prefix string, fn func(key string, value []byte) error) error
+	DeletePrefix(prefix string) (int, error)
+	Namespace(name string) Client
+	Stats() Stats
+	Backup(w io.Writer, since uint64) (uint64, error)
+	Restore(r io.Reader) error
 }
 
 type Badger struct {
-	db *badger.DB
+	db      *badger.DB
+	options BadgerOptions
+
+	stop      chan struct{}
+	done      chan struct{}
+	closeOnce sync.Once
+	closeErr  error
+
+	hits   atomic.Uint64
+	misses atomic.Uint64
+	gcRuns atomic.Uint64
 }
 
 func CreateBadgerClient() (Client, error) {
-	uuid := uuid2.New().String()
-	badgerPath := filepath.Join("/tmp", "badger", uuid)
-	badger, err := NewBadgerClient(badgerPath)
-	if err != nil {
-		return nil, err
-	}
-
-	return badger, nil
+	opts := DefaultBadgerOptions()
+	opts.DeleteOnClose = true
+	return newBadger(opts)
 }
 
 func NewInMemoryBadgerClient() (Client, error) {
-	opt := badger.DefaultOptions("").WithInMemory(true)
-	db, err := badger.Open(opt)
-	if err != nil {
-		return nil, err
-	}
-
-	badgerDb := Badger{db: db}
-
-	return &badgerDb, nil
+	opts := DefaultBadgerOptions()
+	opts.InMemory = true
+	return newBadger(opts)
 }
 
 func NewBadgerClient(badgerPath string) (Client, error) {
-	db, err := badger.Open(badger.DefaultOptions(badgerPath))
-	if err != nil {
-		return nil, err
-	}
-
-	badgerDb := Badger{db: db}
-
-	return &badgerDb, nil
+	opts := DefaultBadgerOptions()
+	opts.Path = badgerPath
+	return newBadger(opts)
 }
@@ -0,0 +1,163 @@
+package archytas
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+)
+
+// reservedKeyMarker starts the keys archytas stores for its own bookkeeping.
+// Keys and Scan skip them; DeletePrefix removes them along with the entries.
+const (
+	reservedKeyMarker = "\x00archytas-"
+	negativeKeyPrefix = reservedKeyMarker + "negative:"
+)
+
+type LoaderFunc func(ctx context.Context) ([]byte, error)
+
+type ReadThroughOptions struct {
+	// NegativeTTL caches a loader result of ErrNotFound for this long, so repeated
+	// lookups for unknown words do not reach the backing store. Zero disables it.
+	NegativeTTL time.Duration
+	// StaleFor keeps values around this long after their ttl has passed. Within that
+	// window the stale value is returned immediately and refreshed in the background.
+	StaleFor time.Duration
+}
+
+type ReadThrough struct {
+	client  Client
+	options ReadThroughOptions
+	flight  flightGroup
+}
+
+func NewReadThrough(client Client, options ReadThroughOptions) *ReadThrough {
+	return &ReadThrough{client: client, options: options}
+}
+
+// GetOrLoad reads key from the cache and falls back to loader on a miss. Concurrent
+// misses for the same key share a single loader call. A loader returning an error
+// wrapping ErrNotFound is treated as a definitive "does not exist" and negatively
+// cached when NegativeTTL is set; other loader errors are returned and never cached.
+func (r *ReadThrough) GetOrLoad(ctx context.Context, key string, ttl time.Duration, loader LoaderFunc) ([]byte, error) {
+	value, remaining, err := r.client.ReadWithTTL(key)
+	if err == nil {
+		if r.options.StaleFor > 0 && remaining > 0 && remaining <= r.options.StaleFor {
+			r.refresh(ctx, key, ttl, loader)
+		}
+		return value, nil
+	}
+	if !errors.Is(err, ErrNotFound) {
+		return nil, err
+	}
+
+	if r.options.NegativeTTL > 0 {
+		negative, err := r.client.Exists(negativeKey(key))
+		if err != nil {
+			return nil, err
+		}
+		if negative {
+			return nil, fmt.Errorf("%w: %q (negatively cached)", ErrNotFound, key)
+		}
+	}
+
+	return r.flight.do(ctx, key, func(ctx context.Context) ([]byte, error) {
+		return r.load(ctx, key, ttl, loader)
+	})
+}
+
+func (r *ReadThrough) refresh(ctx context.Context, key string, ttl time.Duration, loader LoaderFunc) {
+	background := context.WithoutCancel(ctx)
+	go func() {
+		_, _ = r.flight.do(background, key, func(ctx context.Context) ([]byte, error) {
+			return r.load(ctx, key, ttl, loader)
+		})
+	}()
+}
+
+func (r *ReadThrough) load(ctx context.Context, key string, ttl time.Duration, loader LoaderFunc) ([]byte, error) {
+	value, err := loader(ctx)
+	if err != nil {
+		if errors.Is(err, ErrNotFound) && r.options.NegativeTTL > 0 {
+			if setErr := r.client.SetWithTTL(negativeKey(key), "", r.options.NegativeTTL); setErr != nil {
+				return nil, errors.Join(err, setErr)
+			}
+		}
+		return nil, err
+	}
+
+	// a zero ttl never expires, so there is no stale window to add
+	if ttl > 0 {
+		err = r.client.SetWithTTL(key, string(value), ttl+r.options.StaleFor)
+	} else {
+		err = r.client.Set(key, string(value))
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return value, nil
+}
+
+func negativeKey(key string) string {
+	return negativeKeyPrefix + key
+}
+
+// isReservedKey also matches reserved keys written through a namespace, which
+// carry the namespace prefix in front of the marker.
+func isReservedKey(key string) bool {
+	return strings.Contains(key, reservedKeyMarker)
+}
+
+type flightCall struct {
+	done  chan struct{}
+	value []byte
+	err   error
+}
+
+// flightGroup deduplicates concurrent calls for the same key; callers that arrive
+// while a call is in flight wait for its result instead of starting their own.
+// The shared call does not stop when the caller that started it gives up, every
+// caller only stops waiting on its own ctx.
+type flightGroup struct {
+	mu    sync.Mutex
+	calls map[string]*flightCall
+}
+
+func (g *flightGroup) do(ctx context.Context, key string, fn func(ctx context.Context) ([]byte, error)) ([]byte, error) {
+	g.mu.Lock()
+	if g.calls == nil {
+		g.calls = make(map[string]*flightCall)
+	}
+	call, ok := g.calls[key]
+	if !ok {
+		call = &flightCall{done: make(chan struct{})}
+		g.calls[key] = call
+		go g.run(context.WithoutCancel(ctx), key, call, fn)
+	}
+	g.mu.Unlock()
+
+	select {
+	case <-call.done:
+		return call.value, call.err
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+func (g *flightGroup) run(ctx context.Context, key string, call *flightCall, fn func(ctx context.Context) ([]byte, error)) {
+	defer func() {
+		if recovered := recover(); recovered != nil {
+			call.value, call.err = nil, fmt.Errorf("archytas: loader for %q panicked: %v", key, recovered)
+		}
+
+		g.mu.Lock()
+		delete(g.calls, key)
+		g.mu.Unlock()
+		close(call.done)
+	}()
+
+	call.value, call.err = fn(ctx)
+}
@@ -0,0 +1,284 @@
+package archytas
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReadThrough(t *testing.T) {
+	key := "λόγος"
+	value := []byte("word")
+
+	t.Run("LoadsOnMissAndCaches", func(t *testing.T) {
+		testClient, err := NewInMemoryBadgerClient()
+		assert.Nil(t, err)
+		defer testClient.Close()
+
+		var calls int32
+		loader := func(ctx context.Context) ([]byte, error) {
+			atomic.AddInt32(&calls, 1)
+			return value, nil
+		}
+
+		cache := NewReadThrough(testClient, ReadThroughOptions{})
+		for i := 0; i < 3; i++ {
+			sut, err := cache.GetOrLoad(context.Background(), key, time.Minute, loader)
+			assert.Nil(t, err)
+			assert.Equal(t, value, sut)
+		}
+		assert.Equal(t, int32(1), atomic.LoadInt32(&calls))
+
+		stored, err := testClient.Read(key)
+		assert.Nil(t, err)
+		assert.Equal(t, value, stored)
+	})
+
+	t.Run("DeduplicatesConcurrentLoads", func(t *testing.T) {
+		testClient, err := NewInMemoryBadgerClient()
+		assert.Nil(t, err)
+		defer testClient.Close()
+
+		var calls int32
+		release := make(chan struct{})
+		loader := func(ctx context.Context) ([]byte, error) {
+			atomic.AddInt32(&calls, 1)
+			<-release
+			return value, nil
+		}
+
+		cache := NewReadThrough(testClient, ReadThroughOptions{})
+		var wg sync.WaitGroup
+		for i := 0; i < 20; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				sut, err := cache.GetOrLoad(context.Background(), key, time.Minute, loader)
+				assert.Nil(t, err)
+				assert.Equal(t, value, sut)
+			}()
+		}
+
+		time.Sleep(50 * time.Millisecond)
+		close(release)
+		wg.Wait()
+		assert.Equal(t, int32(1), atomic.LoadInt32(&calls))
+	})
+
+	t.Run("LoaderErrorsAreNotCached", func(t *testing.T) {
+		testClient, err := NewInMemoryBadgerClient()
+		assert.Nil(t, err)
+		defer testClient.Close()
+
+		boom := errors.New("elastic unavailable")
+		cache := NewReadThrough(testClient, ReadThroughOptions{NegativeTTL: time.Minute})
+		_, err = cache.GetOrLoad(context.Background(), key, time.Minute, func(ctx context.Context) ([]byte, error) {
+			return nil, boom
+		})
+		assert.True(t, errors.Is(err, boom))
+
+		sut, err := cache.GetOrLoad(context.Background(), key, time.Minute, func(ctx context.Context) ([]byte, error) {
+			return value, nil
+		})
+		assert.Nil(t, err)
+		assert.Equal(t, value, sut)
+	})
+
+	t.Run("NegativeCaching", func(t *testing.T) {
+		testClient, err := NewInMemoryBadgerClient()
+		assert.Nil(t, err)
+		defer testClient.Close()
+
+		var calls int32
+		loader := func(ctx context.Context) ([]byte, error) {
+			atomic.AddInt32(&calls, 1)
+			return nil, fmt.Errorf("no such word: %w", ErrNotFound)
+		}
+
+		cache := NewReadThrough(testClient, ReadThroughOptions{NegativeTTL: time.Minute})
+		for i := 0; i < 3; i++ {
+			_, err := cache.GetOrLoad(context.Background(), key, time.Minute, loader)
+			assert.True(t, errors.Is(err, ErrNotFound))
+		}
+		assert.Equal(t, int32(1), atomic.LoadInt32(&calls))
+	})
+
+	t.Run("NegativeMarkersAreHidden", func(t *testing.T) {
+		badgerClient, err := NewInMemoryBadgerClient()
+		assert.Nil(t, err)
+		defer badgerClient.Close()
+		memoryClient, err := NewMemoryClient(MemoryOptions{})
+		assert.Nil(t, err)
+
+		loader := func(ctx context.Context) ([]byte, error) {
+			return nil, ErrNotFound
+		}
+
+		for name, testClient := range map[string]Client{"Badger": badgerClient, "Memory": memoryClient, "Namespace": badgerClient.Namespace("words")} {
+			t.Run(name, func(t *testing.T) {
+				assert.Nil(t, testClient.Set("ἀγάπη", "love"))
+				cache := NewReadThrough(testClient, ReadThroughOptions{NegativeTTL: time.Minute})
+				_, err := cache.GetOrLoad(context.Background(), key, time.Minute, loader)
+				assert.True(t, errors.Is(err, ErrNotFound))
+
+				keys, err := testClient.Keys("")
+				assert.Nil(t, err)
+				assert.Equal(t, []string{"ἀγάπη"}, keys)
+
+				var scanned []string
+				assert.Nil(t, testClient.Scan("", func(key string, value []byte) error {
+					scanned = append(scanned, key)
+					return nil
+				}))
+				assert.Equal(t, []string{"ἀγάπη"}, scanned)
+
+				_, err = testClient.DeletePrefix("")
+				assert.Nil(t, err)
+				exists, err := testClient.Exists(negativeKey(key))
+				assert.Nil(t, err)
+				assert.False(t, exists)
+			})
+		}
+	})
+
+	t.Run("ZeroTTLIsNeverStale", func(t *testing.T) {
+		testClient, err := NewInMemoryBadgerClient()
+		assert.Nil(t, err)
+		defer testClient.Close()
+
+		var calls int32
+		loader := func(ctx context.Context) ([]byte, error) {
+			atomic.AddInt32(&calls, 1)
+			return value, nil
+		}
+
+		cache := NewReadThrough(testClient, ReadThroughOptions{StaleFor: time.Minute})
+		for i := 0; i < 3; i++ {
+			sut, err := cache.GetOrLoad(context.Background(), key, 0, loader)
+			assert.Nil(t, err)
+			assert.Equal(t, value, sut)
+		}
+
+		_, ttl, err := testClient.ReadWithTTL(key)
+		assert.Nil(t, err)
+		assert.Equal(t, time.Duration(0), ttl)
+		time.Sleep(20 * time.Millisecond)
+		assert.Equal(t, int32(1), atomic.LoadInt32(&calls))
+	})
+
+	t.Run("ServesStaleWhileRefreshing", func(t *testing.T) {
+		testClient, err := NewInMemoryBadgerClient()
+		assert.Nil(t, err)
+		defer testClient.Close()
+
+		err = testClient.SetWithTTL(key, "stale", 30*time.Second)
+		assert.Nil(t, err)
+
+		refreshed := make(chan struct{})
+		cache := NewReadThrough(testClient, ReadThroughOptions{StaleFor: time.Minute})
+		sut, err := cache.GetOrLoad(context.Background(), key, time.Hour, func(ctx context.Context) ([]byte, error) {
+			defer close(refreshed)
+			return value, nil
+		})
+		assert.Nil(t, err)
+		assert.Equal(t, "stale", string(sut))
+
+		select {
+		case <-refreshed:
+		case <-time.After(time.Second):
+			t.Fatal("background refresh did not run")
+		}
+
+		assert.Eventually(t, func() bool {
+			stored, err := testClient.Read(key)
+			return err == nil && string(stored) == string(value)
+		}, time.Second, 10*time.Millisecond)
+	})
+
+	t.Run("WaitingCallerRespectsContext", func(t *testing.T) {
+		testClient, err := NewInMemoryBadgerClient()
+		assert.Nil(t, err)
+		defer testClient.Close()
+
+		release := make(chan struct{})
+		defer close(release)
+		cache := NewReadThrough(testClient, ReadThroughOptions{})
+		go cache.GetOrLoad(context.Background(), key, time.Minute, func(ctx context.Context) ([]byte, error) {
+			<-release
+			return value, nil
+		})
+		time.Sleep(20 * time.Millisecond)
+
+		ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+		defer cancel()
+		_, err = cache.GetOrLoad(ctx, key, time.Minute, func(ctx context.Context) ([]byte, error) {
+			return value, nil
+		})
+		assert.True(t, errors.Is(err, context.DeadlineExceeded))
+	})
+
+	t.Run("FirstCallerCancelDoesNotFailWaiters", func(t *testing.T) {
+		testClient, err := NewInMemoryBadgerClient()
+		assert.Nil(t, err)
+		defer testClient.Close()
+
+		release := make(chan struct{})
+		loader := func(ctx context.Context) ([]byte, error) {
+			select {
+			case <-release:
+				return value, nil
+			case <-ctx.Done():
+				return nil, ctx.Err()
+			}
+		}
+
+		cache := NewReadThrough(testClient, ReadThroughOptions{})
+		ctx, cancel := context.WithCancel(context.Background())
+		first := make(chan error, 1)
+		go func() {
+			_, err := cache.GetOrLoad(ctx, key, time.Minute, loader)
+			first <- err
+		}()
+		time.Sleep(20 * time.Millisecond)
+
+		waiter := make(chan error, 1)
+		go func() {
+			sut, err := cache.GetOrLoad(context.Background(), key, time.Minute, loader)
+			if err == nil && string(sut) != string(value) {
+				err = fmt.Errorf("unexpected value %q", sut)
+			}
+			waiter <- err
+		}()
+		time.Sleep(20 * time.Millisecond)
+
+		cancel()
+		assert.True(t, errors.Is(<-first, context.Canceled))
+		close(release)
+		assert.Nil(t, <-waiter)
+	})
+
+	t.Run("PanickingLoaderFailsCallers", func(t *testing.T) {
+		testClient, err := NewInMemoryBadgerClient()
+		assert.Nil(t, err)
+		defer testClient.Close()
+
+		cache := NewReadThrough(testClient, ReadThroughOptions{})
+		_, err = cache.GetOrLoad(context.Background(), key, time.Minute, func(ctx context.Context) ([]byte, error) {
+			panic("no backing store")
+		})
+		assert.NotNil(t, err)
+		assert.Contains(t, err.Error(), "panicked")
+
+		sut, err := cache.GetOrLoad(context.Background(), key, time.Minute, func(ctx context.Context) ([]byte, error) {
+			return value, nil
+		})
+		assert.Nil(t, err)
+		assert.Equal(t, value, sut)
+	})
+}
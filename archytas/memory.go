@@ -0,0 +1,447 @@
+package archytas
+
+import (
+	"container/heap"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+var (
+	ErrClosed        = errors.New("archytas: cache closed")
+	ErrValueTooLarge = errors.New("archytas: entry exceeds cache size limit")
+)
+
+type EvictionPolicy int
+
+const (
+	LRU EvictionPolicy = iota
+	LFU
+)
+
+// MemoryOptions bounds the in-memory backend. A zero MaxEntries or MaxBytes means that
+// dimension is unbounded; the size of an entry is len(key)+len(value).
+type MemoryOptions struct {
+	MaxEntries int
+	MaxBytes   int64
+	Policy     EvictionPolicy
+}
+
+type Memory struct {
+	mu      sync.Mutex
+	options MemoryOptions
+	entries map[string]*memoryEntry
+	order   memoryHeap
+	bytes   int64
+	tick    uint64
+	closed  bool
+
+	hits        atomic.Uint64
+	misses      atomic.Uint64
+	evictions   atomic.Uint64
+	expirations atomic.Uint64
+}
+
+type memoryEntry struct {
+	key       string
+	value     []byte
+	expiresAt time.Time
+	lastUsed  uint64
+	frequency uint64
+	index     int
+}
+
+func (e *memoryEntry) size() int64 {
+	return int64(len(e.key) + len(e.value))
+}
+
+func (e *memoryEntry) expired(now time.Time) bool {
+	return !e.expiresAt.IsZero() && !now.Before(e.expiresAt)
+}
+
+func NewMemoryClient(options MemoryOptions) (Client, error) {
+	return newMemory(options)
+}
+
+func newMemory(options MemoryOptions) (*Memory, error) {
+	if options.MaxEntries < 0 || options.MaxBytes < 0 {
+		return nil, fmt.Errorf("archytas: memory limits cannot be negative")
+	}
+	if options.Policy != LRU && options.Policy != LFU {
+		return nil, fmt.Errorf("archytas: unknown eviction policy %d", options.Policy)
+	}
+
+	m := &Memory{
+		options: options,
+		entries: make(map[string]*memoryEntry),
+	}
+	m.order.policy = options.Policy
+
+	return m, nil
+}
+
+func (m *Memory) Evictions() uint64 {
+	return m.evictions.Load()
+}
+
+func (m *Memory) Expirations() uint64 {
+	return m.expirations.Load()
+}
+
+func (m *Memory) Len() int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return len(m.entries)
+}
+
+func (m *Memory) Close() error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.closed = true
+	m.entries = make(map[string]*memoryEntry)
+	m.order.items = nil
+	m.bytes = 0
+	return nil
+}
+
+func (m *Memory) Set(key, value string) error {
+	return m.SetWithTTL(key, value, 0)
+}
+
+func (m *Memory) SetWithTTL(key, value string, ttl time.Duration) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.closed {
+		return ErrClosed
+	}
+
+	return m.set(key, []byte(value), ttl, time.Now())
+}
+
+func (m *Memory) Read(key string) ([]byte, error) {
+	value, _, err := m.ReadWithTTL(key)
+	return value, err
+}
+
+func (m *Memory) ReadWithTTL(key string) ([]byte, time.Duration, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.closed {
+		return nil, 0, ErrClosed
+	}
+
+	now := time.Now()
+	entry, ok := m.get(key, now)
+	if !ok {
+		m.misses.Add(1)
+		return nil, 0, fmt.Errorf("%w: %q", ErrNotFound, key)
+	}
+
+	m.hits.Add(1)
+	m.touch(entry)
+
+	var ttl time.Duration
+	if !entry.expiresAt.IsZero() {
+		ttl = entry.expiresAt.Sub(now)
+	}
+
+	return append([]byte{}, entry.value...), ttl, nil
+}
+
+func (m *Memory) Exists(key string) (bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.closed {
+		return false, ErrClosed
+	}
+
+	_, ok := m.get(key, time.Now())
+	return ok, nil
+}
+
+func (m *Memory) SetMany(items map[string]string, ttl time.Duration) (*BatchResult, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.closed {
+		return nil, ErrClosed
+	}
+
+	result := newBatchResult()
+	now := time.Now()
+	for key, value := range items {
+		if err := m.set(key, []byte(value), ttl, now); err != nil {
+			result.Errors[key] = err
+		}
+	}
+
+	return result, nil
+}
+
+func (m *Memory) ReadMany(keys []string) (*BatchResult, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.closed {
+		return nil, ErrClosed
+	}
+
+	result := newBatchResult()
+	now := time.Now()
+	for _, key := range keys {
+		entry, ok := m.get(key, now)
+		if !ok {
+			m.misses.Add(1)
+			result.Errors[key] = fmt.Errorf("%w: %q", ErrNotFound, key)
+			continue
+		}
+		m.hits.Add(1)
+		m.touch(entry)
+		result.Values[key] = append([]byte{}, entry.value...)
+	}
+
+	return result, nil
+}
+
+func (m *Memory) DeleteMany(keys []string) (*BatchResult, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.closed {
+		return nil, ErrClosed
+	}
+
+	for _, key := range keys {
+		if entry, ok := m.entries[key]; ok {
+			m.remove(entry)
+		}
+	}
+
+	return newBatchResult(), nil
+}
+
+func (m *Memory) Keys(prefix string) ([]string, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.closed {
+		return nil, ErrClosed
+	}
+
+	return m.visibleKeys(prefix, time.Now()), nil
+}
+
+func (m *Memory) Scan(prefix string, fn func(key string, value []byte) error) error {
+	return m.scanWithTTL(prefix, func(key string, value []byte, ttl time.Duration) error {
+		return fn(key, value)
+	})
+}
+
+// scanWithTTL walks the live entries with prefix without counting hits or touching
+// the eviction order. Reserved keys are skipped.
+func (m *Memory) scanWithTTL(prefix string, fn func(key string, value []byte, ttl time.Duration) error) error {
+	m.mu.Lock()
+	if m.closed {
+		m.mu.Unlock()
+		return ErrClosed
+	}
+
+	now := time.Now()
+	keys := m.visibleKeys(prefix, now)
+	values := make([][]byte, len(keys))
+	ttls := make([]time.Duration, len(keys))
+	for i, key := range keys {
+		entry := m.entries[key]
+		values[i] = append([]byte{}, entry.value...)
+		if !entry.expiresAt.IsZero() {
+			ttls[i] = entry.expiresAt.Sub(now)
+		}
+	}
+	m.mu.Unlock()
+
+	for i, key := range keys {
+		if err := fn(key, values[i], ttls[i]); err != nil {
+			if errors.Is(err, ErrStopScan) {
+				return nil
+			}
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (m *Memory) DeletePrefix(prefix string) (int, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.closed {
+		return 0, ErrClosed
+	}
+
+	keys := m.keys(prefix, time.Now())
+	for _, key := range keys {
+		m.remove(m.entries[key])
+	}
+
+	return len(keys), nil
+}
+
+func (m *Memory) Namespace(name string) Client {
+	return newNamespace(m, name)
+}
+
+// keys returns the live keys with prefix in lexical order, matching badger iteration.
+func (m *Memory) keys(prefix string, now time.Time) []string {
+	var keys []string
+	for key, entry := range m.entries {
+		if !strings.HasPrefix(key, prefix) {
+			continue
+		}
+		if entry.expired(now) {
+			m.expire(entry)
+			continue
+		}
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	return keys
+}
+
+// visibleKeys is keys without the reserved bookkeeping entries.
+func (m *Memory) visibleKeys(prefix string, now time.Time) []string {
+	keys := m.keys(prefix, now)
+	visible := keys[:0]
+	for _, key := range keys {
+		if !isReservedKey(key) {
+			visible = append(visible, key)
+		}
+	}
+
+	return visible
+}
+
+func (m *Memory) get(key string, now time.Time) (*memoryEntry, bool) {
+	entry, ok := m.entries[key]
+	if !ok {
+		return nil, false
+	}
+	if entry.expired(now) {
+		m.expire(entry)
+		return nil, false
+	}
+
+	return entry, true
+}
+
+func (m *Memory) set(key string, value []byte, ttl time.Duration, now time.Time) error {
+	entry := &memoryEntry{key: key, value: value}
+	// drop the old value first, a rejected overwrite must not leave it readable
+	if existing, ok := m.entries[key]; ok {
+		entry.frequency = existing.frequency
+		m.remove(existing)
+	}
+	if m.options.MaxBytes > 0 && entry.size() > m.options.MaxBytes {
+		return fmt.Errorf("%w: %q is %d bytes", ErrValueTooLarge, key, entry.size())
+	}
+	if ttl > 0 {
+		entry.expiresAt = now.Add(ttl)
+	}
+
+	// make room before inserting, otherwise LFU would evict the new entry right away
+	for m.order.Len() > 0 && m.overLimit(1, entry.size()) {
+		victim := m.order.items[0]
+		if victim.expired(now) {
+			m.expire(victim)
+			continue
+		}
+		m.remove(victim)
+		m.evictions.Add(1)
+	}
+
+	m.tick++
+	entry.lastUsed = m.tick
+	entry.frequency++
+	m.entries[key] = entry
+	heap.Push(&m.order, entry)
+	m.bytes += entry.size()
+
+	return nil
+}
+
+func (m *Memory) overLimit(extraEntries int, extraBytes int64) bool {
+	if m.options.MaxEntries > 0 && len(m.entries)+extraEntries > m.options.MaxEntries {
+		return true
+	}
+
+	return m.options.MaxBytes > 0 && m.bytes+extraBytes > m.options.MaxBytes
+}
+
+func (m *Memory) touch(entry *memoryEntry) {
+	m.tick++
+	entry.lastUsed = m.tick
+	entry.frequency++
+	heap.Fix(&m.order, entry.index)
+}
+
+func (m *Memory) expire(entry *memoryEntry) {
+	m.remove(entry)
+	m.expirations.Add(1)
+}
+
+func (m *Memory) remove(entry *memoryEntry) {
+	heap.Remove(&m.order, entry.index)
+	delete(m.entries, entry.key)
+	m.bytes -= entry.size()
+}
+
+// memoryHeap keeps the next eviction candidate at the root: the least recently used
+// entry for LRU, or the least frequently used (oldest first on ties) for LFU.
+type memoryHeap struct {
+	policy EvictionPolicy
+	items  []*memoryEntry
+}
+
+func (h *memoryHeap) Len() int {
+	return len(h.items)
+}
+
+func (h *memoryHeap) Less(i, j int) bool {
+	a, b := h.items[i], h.items[j]
+	if h.policy == LFU && a.frequency != b.frequency {
+		return a.frequency < b.frequency
+	}
+
+	return a.lastUsed < b.lastUsed
+}
+
+func (h *memoryHeap) Swap(i, j int) {
+	h.items[i], h.items[j] = h.items[j], h.items[i]
+	h.items[i].index = i
+	h.items[j].index = j
+}
+
+func (h *memoryHeap) Push(x any) {
+	entry := x.(*memoryEntry)
+	entry.index = len(h.items)
+	h.items = append(h.items, entry)
+}
+
+func (h *memoryHeap) Pop() any {
+	old := h.items
+	n := len(old)
+	entry := old[n-1]
+	old[n-1] = nil
+	h.items = old[:n-1]
+	entry.index = -1
+	return entry
+}
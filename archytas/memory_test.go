@@ -0,0 +1,151 @@
+package archytas
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMemoryClient(t *testing.T) {
+	key := "testkey"
+	value := "testvalue"
+
+	t.Run("ReadValue", func(t *testing.T) {
+		testClient, err := NewMemoryClient(MemoryOptions{})
+		assert.Nil(t, err)
+
+		err = testClient.Set(key, value)
+		assert.Nil(t, err)
+
+		sut, err := testClient.Read(key)
+		assert.Nil(t, err)
+		assert.Equal(t, value, string(sut))
+		testClient.Close()
+	})
+
+	t.Run("ReadEmptyValue", func(t *testing.T) {
+		testClient, err := NewMemoryClient(MemoryOptions{})
+		assert.Nil(t, err)
+
+		sut, err := testClient.Read(key)
+		assert.True(t, errors.Is(err, ErrNotFound))
+		assert.Nil(t, sut)
+		testClient.Close()
+	})
+
+	t.Run("SetValueWithTTL", func(t *testing.T) {
+		testClient, err := NewMemoryClient(MemoryOptions{})
+		assert.Nil(t, err)
+
+		err = testClient.SetWithTTL(key, value, 20*time.Millisecond)
+		assert.Nil(t, err)
+
+		_, ttl, err := testClient.ReadWithTTL(key)
+		assert.Nil(t, err)
+		assert.True(t, ttl > 0)
+
+		time.Sleep(30 * time.Millisecond)
+		sut, err := testClient.Read(key)
+		assert.True(t, errors.Is(err, ErrNotFound))
+		assert.Nil(t, sut)
+		assert.Equal(t, uint64(1), testClient.(*Memory).Expirations())
+		testClient.Close()
+	})
+
+	t.Run("LRUEviction", func(t *testing.T) {
+		testClient, err := NewMemoryClient(MemoryOptions{MaxEntries: 2, Policy: LRU})
+		assert.Nil(t, err)
+
+		assert.Nil(t, testClient.Set("a", "1"))
+		assert.Nil(t, testClient.Set("b", "2"))
+		_, err = testClient.Read("a")
+		assert.Nil(t, err)
+		assert.Nil(t, testClient.Set("c", "3"))
+
+		exists, _ := testClient.Exists("b")
+		assert.False(t, exists)
+		exists, _ = testClient.Exists("a")
+		assert.True(t, exists)
+		assert.Equal(t, uint64(1), testClient.(*Memory).Evictions())
+		testClient.Close()
+	})
+
+	t.Run("LFUEviction", func(t *testing.T) {
+		testClient, err := NewMemoryClient(MemoryOptions{MaxEntries: 2, Policy: LFU})
+		assert.Nil(t, err)
+
+		assert.Nil(t, testClient.Set("a", "1"))
+		assert.Nil(t, testClient.Set("b", "2"))
+		for i := 0; i < 3; i++ {
+			_, err = testClient.Read("a")
+			assert.Nil(t, err)
+		}
+		_, err = testClient.Read("b")
+		assert.Nil(t, err)
+		assert.Nil(t, testClient.Set("c", "3"))
+		assert.Nil(t, testClient.Set("d", "4"))
+
+		keys, err := testClient.Keys("")
+		assert.Nil(t, err)
+		assert.Equal(t, []string{"a", "d"}, keys)
+		assert.Equal(t, uint64(2), testClient.(*Memory).Evictions())
+		testClient.Close()
+	})
+
+	t.Run("MaxBytes", func(t *testing.T) {
+		testClient, err := NewMemoryClient(MemoryOptions{MaxBytes: 10})
+		assert.Nil(t, err)
+
+		assert.Nil(t, testClient.Set("a", "1234"))
+		assert.Nil(t, testClient.Set("b", "1234"))
+		assert.Nil(t, testClient.Set("c", "1234"))
+		assert.Equal(t, 2, testClient.(*Memory).Len())
+
+		err = testClient.Set("d", "this value is far too large")
+		assert.True(t, errors.Is(err, ErrValueTooLarge))
+
+		err = testClient.Set("b", "this value is far too large")
+		assert.True(t, errors.Is(err, ErrValueTooLarge))
+		_, err = testClient.Read("b")
+		assert.True(t, errors.Is(err, ErrNotFound))
+		testClient.Close()
+	})
+
+	t.Run("ScanAndNamespace", func(t *testing.T) {
+		testClient, err := NewMemoryClient(MemoryOptions{})
+		assert.Nil(t, err)
+
+		dionysios := testClient.Namespace("dionysios")
+		_, err = dionysios.SetMany(map[string]string{"λόγος": "noun", "λέγω": "verb"}, 0)
+		assert.Nil(t, err)
+		assert.Nil(t, testClient.Set("alexandros:λόγος", "word"))
+
+		var scanned []string
+		err = dionysios.Scan("", func(key string, value []byte) error {
+			scanned = append(scanned, key)
+			return nil
+		})
+		assert.Nil(t, err)
+		assert.Equal(t, []string{"λέγω", "λόγος"}, scanned)
+
+		deleted, err := dionysios.DeletePrefix("")
+		assert.Nil(t, err)
+		assert.Equal(t, 2, deleted)
+
+		keys, err := testClient.Keys("")
+		assert.Nil(t, err)
+		assert.Equal(t, []string{"alexandros:λόγος"}, keys)
+		testClient.Close()
+	})
+
+	t.Run("ClosedClient", func(t *testing.T) {
+		testClient, err := NewMemoryClient(MemoryOptions{})
+		assert.Nil(t, err)
+		testClient.Close()
+
+		err = testClient.Set(key, value)
+		assert.True(t, errors.Is(err, ErrClosed))
+	})
+}
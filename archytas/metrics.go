@@ -0,0 +1,91 @@
+package archytas
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+)
+
+type metric struct {
+	name  string
+	help  string
+	kind  string
+	value func(s Stats) float64
+}
+
+var cacheMetrics = []metric{
+	{"archytas_cache_hits_total", "Number of reads that found a value.", "counter", func(s Stats) float64 { return float64(s.Hits) }},
+	{"archytas_cache_misses_total", "Number of reads that found no value.", "counter", func(s Stats) float64 { return float64(s.Misses) }},
+	{"archytas_cache_hit_ratio", "Hits divided by all reads since start.", "gauge", func(s Stats) float64 { return s.HitRatio() }},
+	{"archytas_cache_evictions_total", "Number of entries evicted to stay within size limits.", "counter", func(s Stats) float64 { return float64(s.Evictions) }},
+	{"archytas_cache_expirations_total", "Number of entries dropped because their ttl passed.", "counter", func(s Stats) float64 { return float64(s.Expirations) }},
+	{"archytas_cache_entries", "Number of entries held in memory.", "gauge", func(s Stats) float64 { return float64(s.Entries) }},
+	{"archytas_cache_size_bytes", "Bytes held in memory.", "gauge", func(s Stats) float64 { return float64(s.Bytes) }},
+	{"archytas_badger_lsm_size_bytes", "Size of the badger LSM tree.", "gauge", func(s Stats) float64 { return float64(s.LSMSize) }},
+	{"archytas_badger_vlog_size_bytes", "Size of the badger value log.", "gauge", func(s Stats) float64 { return float64(s.VLogSize) }},
+	{"archytas_badger_gc_runs_total", "Number of value log garbage collections that rewrote a file.", "counter", func(s Stats) float64 { return float64(s.GCRuns) }},
+}
+
+var tierMetrics = []struct {
+	name  string
+	help  string
+	value func(s TierStats) (l1, l2 uint64)
+}{
+	{"archytas_cache_tier_hits_total", "Number of reads served by a tier.", func(s TierStats) (uint64, uint64) { return s.L1Hits, s.L2Hits }},
+	{"archytas_cache_tier_misses_total", "Number of reads a tier could not serve.", func(s TierStats) (uint64, uint64) { return s.L1Misses, s.L2Misses }},
+}
+
+// WriteMetrics writes the stats of every cache in the Prometheus text exposition
+// format, labelled with cache="<name>". Tiered caches also get per-tier counters.
+func WriteMetrics(w io.Writer, caches map[string]Client) error {
+	names := make([]string, 0, len(caches))
+	for name := range caches {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	stats := make(map[string]Stats, len(names))
+	tiers := make(map[string]TierStats)
+	for _, name := range names {
+		stats[name] = caches[name].Stats()
+		if tiered, ok := caches[name].(*Tiered); ok {
+			tiers[name] = tiered.TierStats()
+		}
+	}
+
+	bw := bufio.NewWriter(w)
+	for _, m := range cacheMetrics {
+		fmt.Fprintf(bw, "# HELP %s %s\n# TYPE %s %s\n", m.name, m.help, m.name, m.kind)
+		for _, name := range names {
+			fmt.Fprintf(bw, "%s{cache=%q} %g\n", m.name, name, m.value(stats[name]))
+		}
+	}
+
+	if len(tiers) > 0 {
+		for _, m := range tierMetrics {
+			fmt.Fprintf(bw, "# HELP %s %s\n# TYPE %s counter\n", m.name, m.help, m.name)
+			for _, name := range names {
+				tierStats, ok := tiers[name]
+				if !ok {
+					continue
+				}
+				l1, l2 := m.value(tierStats)
+				fmt.Fprintf(bw, "%s{cache=%q,tier=\"l1\"} %d\n", m.name, name, l1)
+				fmt.Fprintf(bw, "%s{cache=%q,tier=\"l2\"} %d\n", m.name, name, l2)
+			}
+		}
+	}
+
+	return bw.Flush()
+}
+
+func MetricsHandler(caches map[string]Client) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+		if err := WriteMetrics(w, caches); err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+		}
+	})
+}
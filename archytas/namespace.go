@@ -0,0 +1,126 @@
+package archytas
+
+import (
+	"strings"
+	"time"
+)
+
+const namespaceSeparator = ":"
+
+// newNamespace returns a Client that transparently prefixes every key with "<name>:", so
+// several services can share one cache and still list or flush only their own keys.
+// Closing a namespace is a no-op; the underlying client has to be closed by its owner.
+func newNamespace(client Client, name string) Client {
+	return &namespaced{client: client, prefix: name + namespaceSeparator}
+}
+
+type namespaced struct {
+	client Client
+	prefix string
+}
+
+func (n *namespaced) key(key string) string {
+	return n.prefix + key
+}
+
+func (n *namespaced) keys(keys []string) []string {
+	prefixed := make([]string, len(keys))
+	for i, key := range keys {
+		prefixed[i] = n.key(key)
+	}
+
+	return prefixed
+}
+
+func (n *namespaced) strip(result *BatchResult) *BatchResult {
+	if result == nil {
+		return nil
+	}
+
+	stripped := newBatchResult()
+	for key, value := range result.Values {
+		stripped.Values[strings.TrimPrefix(key, n.prefix)] = value
+	}
+	for key, err := range result.Errors {
+		stripped.Errors[strings.TrimPrefix(key, n.prefix)] = err
+	}
+
+	return stripped
+}
+
+func (n *namespaced) Close() error {
+	return nil
+}
+
+func (n *namespaced) Set(key, value string) error {
+	return n.client.Set(n.key(key), value)
+}
+
+func (n *namespaced) SetWithTTL(key, value string, ttl time.Duration) error {
+	return n.client.SetWithTTL(n.key(key), value, ttl)
+}
+
+func (n *namespaced) Read(key string) ([]byte, error) {
+	return n.client.Read(n.key(key))
+}
+
+func (n *namespaced) ReadWithTTL(key string) ([]byte, time.Duration, error) {
+	return n.client.ReadWithTTL(n.key(key))
+}
+
+func (n *namespaced) Exists(key string) (bool, error) {
+	return n.client.Exists(n.key(key))
+}
+
+func (n *namespaced) SetMany(items map[string]string, ttl time.Duration) (*BatchResult, error) {
+	prefixed := make(map[string]string, len(items))
+	for key, value := range items {
+		prefixed[n.key(key)] = value
+	}
+
+	result, err := n.client.SetMany(prefixed, ttl)
+	return n.strip(result), err
+}
+
+func (n *namespaced) ReadMany(keys []string) (*BatchResult, error) {
+	result, err := n.client.ReadMany(n.keys(keys))
+	return n.strip(result), err
+}
+
+func (n *namespaced) DeleteMany(keys []string) (*BatchResult, error) {
+	result, err := n.client.DeleteMany(n.keys(keys))
+	return n.strip(result), err
+}
+
+func (n *namespaced) Keys(prefix string) ([]string, error) {
+	keys, err := n.client.Keys(n.key(prefix))
+	if err != nil {
+		return nil, err
+	}
+
+	for i, key := range keys {
+		keys[i] = strings.TrimPrefix(key, n.prefix)
+	}
+
+	return keys, nil
+}
+
+func (n *namespaced) Scan(prefix string, fn func(key string, value []byte) error) error {
+	return n.client.Scan(n.key(prefix), func(key string, value []byte) error {
+		return fn(strings.TrimPrefix(key, n.prefix), value)
+	})
+}
+
+func (n *namespaced) scanWithTTL(prefix string, fn func(key string, value []byte, ttl time.Duration) error) error {
+	return scanWithTTL(n.client, n.key(prefix), func(key string, value []byte, ttl time.Duration) error {
+		return fn(strings.TrimPrefix(key, n.prefix), value, ttl)
+	})
+}
+
+func (n *namespaced) DeletePrefix(prefix string) (int, error) {
+	return n.client.DeletePrefix(n.key(prefix))
+}
+
+func (n *namespaced) Namespace(name string) Client {
+	return newNamespace(n, name)
+}
@@ -0,0 +1,143 @@
+package archytas
+
+import (
+	"fmt"
+	"path/filepath"
+	"time"
+
+	"github.com/dgraph-io/badger/v3"
+	"github.com/dgraph-io/badger/v3/options"
+	uuid2 "github.com/google/uuid"
+)
+
+type Compression int
+
+const (
+	NoCompression Compression = iota
+	Snappy
+	ZSTD
+)
+
+type BadgerOptions struct {
+	// Path is the directory badger stores its files in. When empty and InMemory is not
+	// set a fresh /tmp/badger/<uuid> directory is used.
+	Path     string
+	InMemory bool
+
+	SyncWrites   bool
+	Compression  Compression
+	MemTableSize int64
+
+	// GCInterval is how often the value log is garbage collected in the background,
+	// zero disables the loop. GCDiscardRatio is passed to RunValueLogGC.
+	GCInterval     time.Duration
+	GCDiscardRatio float64
+
+	// DeleteOnClose removes Path after the database is closed, for caches that are
+	// never reopened after a restart.
+	DeleteOnClose bool
+}
+
+func DefaultBadgerOptions() BadgerOptions {
+	return BadgerOptions{
+		Compression:    Snappy,
+		GCInterval:     5 * time.Minute,
+		GCDiscardRatio: 0.5,
+	}
+}
+
+func (o BadgerOptions) badgerOptions() (badger.Options, error) {
+	if o.InMemory && o.Path != "" {
+		return badger.Options{}, fmt.Errorf("archytas: an in-memory badger cannot have a path (%s)", o.Path)
+	}
+	if o.GCInterval < 0 {
+		return badger.Options{}, fmt.Errorf("archytas: gc interval cannot be negative")
+	}
+	if o.GCInterval > 0 && (o.GCDiscardRatio <= 0 || o.GCDiscardRatio >= 1) {
+		return badger.Options{}, fmt.Errorf("archytas: gc discard ratio must be between 0 and 1, got %v", o.GCDiscardRatio)
+	}
+
+	var compression options.CompressionType
+	switch o.Compression {
+	case NoCompression:
+		compression = options.None
+	case Snappy:
+		compression = options.Snappy
+	case ZSTD:
+		compression = options.ZSTD
+	default:
+		return badger.Options{}, fmt.Errorf("archytas: unknown compression %d", o.Compression)
+	}
+
+	opt := badger.DefaultOptions(o.Path).
+		WithInMemory(o.InMemory).
+		WithSyncWrites(o.SyncWrites).
+		WithCompression(compression)
+	if o.MemTableSize > 0 {
+		opt = opt.WithMemTableSize(o.MemTableSize)
+	}
+
+	return opt, nil
+}
+
+func NewBadgerClientWithOptions(o BadgerOptions) (Client, error) {
+	return newBadger(o)
+}
+
+func newBadger(o BadgerOptions) (*Badger, error) {
+	if !o.InMemory && o.Path == "" {
+		o.Path = filepath.Join("/tmp", "badger", uuid2.New().String())
+	}
+
+	opt, err := o.badgerOptions()
+	if err != nil {
+		return nil, err
+	}
+
+	db, err := badger.Open(opt)
+	if err != nil {
+		return nil, err
+	}
+
+	b := &Badger{db: db, options: o, stop: make(chan struct{}), done: make(chan struct{})}
+	if o.GCInterval > 0 && !o.InMemory {
+		go b.runGC()
+	} else {
+		close(b.done)
+	}
+
+	return b, nil
+}
+
+func (b *Badger) runGC() {
+	defer close(b.done)
+
+	ticker := time.NewTicker(b.options.GCInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-b.stop:
+			return
+		case <-ticker.C:
+			b.collectGarbage()
+		}
+	}
+}
+
+// collectGarbage keeps rewriting value log files until badger reports there is nothing
+// left worth rewriting, as recommended by the badger documentation.
+func (b *Badger) collectGarbage() {
+	for {
+		select {
+		case <-b.stop:
+			return
+		default:
+		}
+
+		if err := b.db.RunValueLogGC(b.options.GCDiscardRatio); err != nil {
+			return
+		}
+		b.gcRuns.Add(1)
+	}
+}
@@ -0,0 +1,78 @@
+package archytas
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	uuid2 "github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBadgerOptions(t *testing.T) {
+	t.Run("DeleteOnClose", func(t *testing.T) {
+		opts := DefaultBadgerOptions()
+		opts.Path = filepath.Join(os.TempDir(), "badger", uuid2.New().String())
+		opts.DeleteOnClose = true
+
+		testClient, err := NewBadgerClientWithOptions(opts)
+		assert.Nil(t, err)
+		assert.Nil(t, testClient.Set("key", "value"))
+
+		_, err = os.Stat(opts.Path)
+		assert.Nil(t, err)
+
+		assert.Nil(t, testClient.Close())
+		_, err = os.Stat(opts.Path)
+		assert.True(t, os.IsNotExist(err))
+		assert.Nil(t, testClient.Close())
+	})
+
+	t.Run("CreateBadgerClientCleansUp", func(t *testing.T) {
+		testClient, err := CreateBadgerClient()
+		assert.Nil(t, err)
+
+		path := testClient.(*Badger).options.Path
+		assert.Nil(t, testClient.Close())
+		_, err = os.Stat(path)
+		assert.True(t, os.IsNotExist(err))
+	})
+
+	t.Run("GCLoopStopsOnClose", func(t *testing.T) {
+		opts := DefaultBadgerOptions()
+		opts.Path = t.TempDir()
+		opts.SyncWrites = true
+		opts.Compression = ZSTD
+		opts.MemTableSize = 16 << 20
+		opts.GCInterval = 5 * time.Millisecond
+
+		testClient, err := NewBadgerClientWithOptions(opts)
+		assert.Nil(t, err)
+		assert.Nil(t, testClient.Set("key", "value"))
+		time.Sleep(20 * time.Millisecond)
+
+		closed := make(chan error)
+		go func() { closed <- testClient.Close() }()
+		select {
+		case err := <-closed:
+			assert.Nil(t, err)
+		case <-time.After(5 * time.Second):
+			t.Fatal("close did not stop the gc loop")
+		}
+
+		_, err = os.Stat(opts.Path)
+		assert.Nil(t, err)
+	})
+
+	t.Run("InvalidOptions", func(t *testing.T) {
+		_, err := NewBadgerClientWithOptions(BadgerOptions{InMemory: true, Path: "/tmp/badger/nope"})
+		assert.NotNil(t, err)
+
+		_, err = NewBadgerClientWithOptions(BadgerOptions{InMemory: true, GCInterval: time.Second})
+		assert.NotNil(t, err)
+
+		_, err = NewBadgerClientWithOptions(BadgerOptions{InMemory: true, Compression: Compression(42)})
+		assert.NotNil(t, err)
+	})
+}
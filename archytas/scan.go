@@ -0,0 +1,104 @@
+package archytas
+
+import (
+	"errors"
+	"time"
+
+	"github.com/dgraph-io/badger/v3"
+)
+
+// ErrStopScan can be returned from a Scan callback to end the iteration early without
+// Scan itself returning an error.
+var ErrStopScan = errors.New("archytas: stop scan")
+
+func (b *Badger) Keys(prefix string) ([]string, error) {
+	return b.keys(prefix, false)
+}
+
+func (b *Badger) keys(prefix string, reserved bool) ([]string, error) {
+	var keys []string
+	err := b.db.View(func(txn *badger.Txn) error {
+		opts := badger.DefaultIteratorOptions
+		opts.PrefetchValues = false
+		opts.Prefix = []byte(prefix)
+
+		it := txn.NewIterator(opts)
+		defer it.Close()
+
+		for it.Rewind(); it.Valid(); it.Next() {
+			key := string(it.Item().KeyCopy(nil))
+			if !reserved && isReservedKey(key) {
+				continue
+			}
+			keys = append(keys, key)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return keys, nil
+}
+
+func (b *Badger) Scan(prefix string, fn func(key string, value []byte) error) error {
+	return b.scanWithTTL(prefix, func(key string, value []byte, ttl time.Duration) error {
+		return fn(key, value)
+	})
+}
+
+// scanWithTTL walks the live entries with prefix without counting hits or misses.
+// Reserved keys are skipped.
+func (b *Badger) scanWithTTL(prefix string, fn func(key string, value []byte, ttl time.Duration) error) error {
+	err := b.db.View(func(txn *badger.Txn) error {
+		opts := badger.DefaultIteratorOptions
+		opts.Prefix = []byte(prefix)
+
+		it := txn.NewIterator(opts)
+		defer it.Close()
+
+		for it.Rewind(); it.Valid(); it.Next() {
+			item := it.Item()
+			key := string(item.KeyCopy(nil))
+			if isReservedKey(key) {
+				continue
+			}
+			value, err := item.ValueCopy(nil)
+			if err != nil {
+				return err
+			}
+
+			var ttl time.Duration
+			if expiresAt := item.ExpiresAt(); expiresAt > 0 {
+				ttl = time.Until(time.Unix(int64(expiresAt), 0))
+			}
+			if err := fn(key, value, ttl); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if errors.Is(err, ErrStopScan) {
+		return nil
+	}
+
+	return err
+}
+
+func (b *Badger) DeletePrefix(prefix string) (int, error) {
+	keys, err := b.keys(prefix, true)
+	if err != nil {
+		return 0, err
+	}
+
+	result, err := b.DeleteMany(keys)
+	if err != nil {
+		return 0, err
+	}
+
+	return len(keys) - len(result.Errors), result.Err()
+}
+
+func (b *Badger) Namespace(name string) Client {
+	return newNamespace(b, name)
+}
@@ -0,0 +1,123 @@
+package archytas
+
+import (
+	"errors"
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBadgerScan(t *testing.T) {
+	seed := func(t *testing.T) Client {
+		testClient, err := NewInMemoryBadgerClient()
+		assert.Nil(t, err)
+
+		_, err = testClient.SetMany(map[string]string{
+			"alexandros:λόγος":  "word",
+			"alexandros:θεός":   "god",
+			"dionysios:λόγος":   "noun",
+			"dionysios:λέγω":    "verb",
+			"dionysios:ἀνήρ":    "noun",
+			"herodotos:chapter": "1",
+		}, 0)
+		assert.Nil(t, err)
+		return testClient
+	}
+
+	t.Run("Keys", func(t *testing.T) {
+		testClient := seed(t)
+		defer testClient.Close()
+
+		sut, err := testClient.Keys("dionysios:")
+		assert.Nil(t, err)
+		assert.Equal(t, 3, len(sut))
+
+		all, err := testClient.Keys("")
+		assert.Nil(t, err)
+		assert.Equal(t, 6, len(all))
+	})
+
+	t.Run("ScanStopsEarly", func(t *testing.T) {
+		testClient := seed(t)
+		defer testClient.Close()
+
+		visited := 0
+		err := testClient.Scan("dionysios:", func(key string, value []byte) error {
+			visited++
+			return ErrStopScan
+		})
+		assert.Nil(t, err)
+		assert.Equal(t, 1, visited)
+	})
+
+	t.Run("ScanPropagatesErrors", func(t *testing.T) {
+		testClient := seed(t)
+		defer testClient.Close()
+
+		boom := errors.New("boom")
+		err := testClient.Scan("", func(key string, value []byte) error {
+			return boom
+		})
+		assert.True(t, errors.Is(err, boom))
+	})
+
+	t.Run("DeletePrefix", func(t *testing.T) {
+		testClient := seed(t)
+		defer testClient.Close()
+
+		deleted, err := testClient.DeletePrefix("alexandros:")
+		assert.Nil(t, err)
+		assert.Equal(t, 2, deleted)
+
+		sut, err := testClient.Keys("")
+		assert.Nil(t, err)
+		assert.Equal(t, 4, len(sut))
+	})
+
+	t.Run("Namespace", func(t *testing.T) {
+		testClient := seed(t)
+		defer testClient.Close()
+
+		dionysios := testClient.Namespace("dionysios")
+		sut, err := dionysios.Read("λόγος")
+		assert.Nil(t, err)
+		assert.Equal(t, "noun", string(sut))
+
+		keys, err := dionysios.Keys("λ")
+		assert.Nil(t, err)
+		sort.Strings(keys)
+		assert.Equal(t, []string{"λέγω", "λόγος"}, keys)
+
+		err = dionysios.Set("θεός", "noun")
+		assert.Nil(t, err)
+		alexandros, err := testClient.Namespace("alexandros").Read("θεός")
+		assert.Nil(t, err)
+		assert.Equal(t, "god", string(alexandros))
+
+		result, err := dionysios.ReadMany([]string{"ἀνήρ", "missing"})
+		assert.Nil(t, err)
+		assert.Equal(t, "noun", string(result.Values["ἀνήρ"]))
+		assert.True(t, errors.Is(result.Errors["missing"], ErrNotFound))
+	})
+
+	t.Run("NamespaceFlushIsIndependent", func(t *testing.T) {
+		testClient := seed(t)
+		defer testClient.Close()
+
+		deleted, err := testClient.Namespace("dionysios").DeletePrefix("")
+		assert.Nil(t, err)
+		assert.Equal(t, 3, deleted)
+
+		remaining, err := testClient.Namespace("alexandros").Keys("")
+		assert.Nil(t, err)
+		assert.Equal(t, 2, len(remaining))
+
+		nested := testClient.Namespace("herodotos").Namespace("books")
+		err = nested.Set("1", "Κλειώ")
+		assert.Nil(t, err)
+		exists, err := testClient.Exists("herodotos:books:1")
+		assert.Nil(t, err)
+		assert.True(t, exists)
+	})
+}
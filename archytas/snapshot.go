@@ -0,0 +1,188 @@
+package archytas
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+)
+
+const restoreBatchSize = 1000
+
+// snapshotLine is one entry of the portable JSON-lines snapshot format. Value is
+// base64 encoded by encoding/json, ExpiresAt is a unix timestamp in seconds and
+// omitted for entries without a ttl.
+type snapshotLine struct {
+	Key       string `json:"key"`
+	Value     []byte `json:"value"`
+	ExpiresAt int64  `json:"expiresAt,omitempty"`
+}
+
+// ExportJSONLines writes every live entry of client as one JSON object per line. The
+// format does not depend on the backend, so a snapshot taken from Badger can be
+// imported into Memory and the other way around. Exporting does not count as reads,
+// so it leaves hit statistics and the eviction order of Memory untouched.
+func ExportJSONLines(client Client, w io.Writer) error {
+	bw := bufio.NewWriter(w)
+	enc := json.NewEncoder(bw)
+	now := time.Now()
+	err := scanWithTTL(client, "", func(key string, value []byte, ttl time.Duration) error {
+		line := snapshotLine{Key: key, Value: value}
+		if ttl > 0 {
+			line.ExpiresAt = now.Add(ttl).Unix()
+		}
+		return enc.Encode(line)
+	})
+	if err != nil {
+		return err
+	}
+
+	return bw.Flush()
+}
+
+// ttlScanner is implemented by the clients of this package to iterate entries with
+// their remaining ttl and without the side effects of a read.
+type ttlScanner interface {
+	scanWithTTL(prefix string, fn func(key string, value []byte, ttl time.Duration) error) error
+}
+
+// scanWithTTL falls back to listing and reading keys for clients implemented outside
+// this package.
+func scanWithTTL(client Client, prefix string, fn func(key string, value []byte, ttl time.Duration) error) error {
+	if scanner, ok := client.(ttlScanner); ok {
+		return scanner.scanWithTTL(prefix, fn)
+	}
+
+	keys, err := client.Keys(prefix)
+	if err != nil {
+		return err
+	}
+	for _, key := range keys {
+		if isReservedKey(key) {
+			continue
+		}
+		value, ttl, err := client.ReadWithTTL(key)
+		if errors.Is(err, ErrNotFound) {
+			// expired or deleted since listing the keys
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		if err := fn(key, value, ttl); err != nil {
+			if errors.Is(err, ErrStopScan) {
+				return nil
+			}
+			return err
+		}
+	}
+
+	return nil
+}
+
+// ImportJSONLines loads a snapshot written by ExportJSONLines. Entries whose expiry has
+// passed are skipped, the others keep their remaining ttl.
+func ImportJSONLines(client Client, r io.Reader) error {
+	dec := json.NewDecoder(r)
+	now := time.Now()
+
+	withoutTTL := make(map[string]string)
+	var withTTL []snapshotLine
+	flush := func() error {
+		if len(withoutTTL) > 0 {
+			result, err := client.SetMany(withoutTTL, 0)
+			if err != nil {
+				return err
+			}
+			if err := result.Err(); err != nil {
+				return err
+			}
+			withoutTTL = make(map[string]string)
+		}
+		for _, line := range withTTL {
+			ttl := time.Unix(line.ExpiresAt, 0).Sub(now)
+			if err := client.SetWithTTL(line.Key, string(line.Value), ttl); err != nil {
+				return err
+			}
+		}
+		withTTL = withTTL[:0]
+		return nil
+	}
+
+	for lineNumber := 1; ; lineNumber++ {
+		var line snapshotLine
+		err := dec.Decode(&line)
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return fmt.Errorf("archytas: invalid snapshot line %d: %w", lineNumber, err)
+		}
+
+		switch {
+		case line.ExpiresAt == 0:
+			withoutTTL[line.Key] = string(line.Value)
+		case time.Unix(line.ExpiresAt, 0).After(now):
+			withTTL = append(withTTL, line)
+		}
+
+		if len(withoutTTL)+len(withTTL) >= restoreBatchSize {
+			if err := flush(); err != nil {
+				return err
+			}
+		}
+	}
+
+	return flush()
+}
+
+// Backup writes a badger stream backup of all versions newer than since and returns
+// the version to pass as since for the next incremental backup.
+func (b *Badger) Backup(w io.Writer, since uint64) (uint64, error) {
+	return b.db.Backup(w, since)
+}
+
+// Restore loads a badger stream backup. Badger requires that no other transactions run
+// while a backup is being loaded, so restore before the cache starts serving.
+func (b *Badger) Restore(r io.Reader) error {
+	return b.db.Load(r, 256)
+}
+
+// Backup of the in-memory backend always writes the JSON-lines format; since is
+// ignored as there is no versioning.
+func (m *Memory) Backup(w io.Writer, since uint64) (uint64, error) {
+	return 0, ExportJSONLines(m, w)
+}
+
+func (m *Memory) Restore(r io.Reader) error {
+	return ImportJSONLines(m, r)
+}
+
+// Backup of a Tiered cache snapshots L2, which holds every entry of L1 as well.
+func (t *Tiered) Backup(w io.Writer, since uint64) (uint64, error) {
+	return t.l2.Backup(w, since)
+}
+
+func (t *Tiered) Restore(r io.Reader) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	err := t.l2.Restore(r)
+	if _, l1Err := t.l1.DeletePrefix(""); l1Err != nil && err == nil {
+		return l1Err
+	}
+
+	return err
+}
+
+// Backup of a namespace only covers its own keys and therefore uses the JSON-lines
+// format, with keys relative to the namespace.
+func (n *namespaced) Backup(w io.Writer, since uint64) (uint64, error) {
+	return 0, ExportJSONLines(n, w)
+}
+
+func (n *namespaced) Restore(r io.Reader) error {
+	return ImportJSONLines(n, r)
+}
@@ -0,0 +1,160 @@
+package archytas
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSnapshot(t *testing.T) {
+	items := map[string]string{"λόγος": "word", "θεός": "god", "ἀνήρ": "man"}
+
+	t.Run("BadgerBackupAndRestore", func(t *testing.T) {
+		source, err := NewInMemoryBadgerClient()
+		assert.Nil(t, err)
+		defer source.Close()
+
+		_, err = source.SetMany(items, 0)
+		assert.Nil(t, err)
+		assert.Nil(t, source.SetWithTTL("ephemeral", "value", time.Hour))
+
+		var buf bytes.Buffer
+		version, err := source.Backup(&buf, 0)
+		assert.Nil(t, err)
+		assert.True(t, version > 0)
+
+		target, err := NewInMemoryBadgerClient()
+		assert.Nil(t, err)
+		defer target.Close()
+
+		assert.Nil(t, target.Restore(&buf))
+		sut, err := target.ReadMany([]string{"λόγος", "θεός", "ἀνήρ"})
+		assert.Nil(t, err)
+		assert.Equal(t, 3, len(sut.Values))
+
+		_, ttl, err := target.ReadWithTTL("ephemeral")
+		assert.Nil(t, err)
+		assert.True(t, ttl > 0)
+	})
+
+	t.Run("JSONLinesAcrossBackends", func(t *testing.T) {
+		source, err := NewInMemoryBadgerClient()
+		assert.Nil(t, err)
+		defer source.Close()
+
+		_, err = source.SetMany(items, 0)
+		assert.Nil(t, err)
+		assert.Nil(t, source.SetWithTTL("ephemeral", "value", time.Hour))
+
+		var buf bytes.Buffer
+		assert.Nil(t, ExportJSONLines(source, &buf))
+		assert.Equal(t, 4, strings.Count(buf.String(), "\n"))
+
+		target, err := NewMemoryClient(MemoryOptions{})
+		assert.Nil(t, err)
+		defer target.Close()
+
+		assert.Nil(t, target.Restore(&buf))
+		sut, err := target.Read("θεός")
+		assert.Nil(t, err)
+		assert.Equal(t, "god", string(sut))
+
+		_, ttl, err := target.ReadWithTTL("ephemeral")
+		assert.Nil(t, err)
+		assert.True(t, ttl > 59*time.Minute)
+	})
+
+	t.Run("ExportHasNoSideEffects", func(t *testing.T) {
+		source, err := NewMemoryClient(MemoryOptions{})
+		assert.Nil(t, err)
+		defer source.Close()
+
+		assert.Nil(t, source.Set("a", "1"))
+		assert.Nil(t, source.SetWithTTL(negativeKey("b"), "", time.Hour))
+		before := source.Stats()
+
+		var buf bytes.Buffer
+		assert.Nil(t, ExportJSONLines(source, &buf))
+		assert.Equal(t, 1, strings.Count(buf.String(), "\n"))
+		assert.NotContains(t, buf.String(), "negative")
+		assert.Equal(t, before, source.Stats())
+	})
+
+	t.Run("ExportKeepsEvictionOrder", func(t *testing.T) {
+		source, err := NewMemoryClient(MemoryOptions{MaxEntries: 2, Policy: LRU})
+		assert.Nil(t, err)
+		defer source.Close()
+
+		assert.Nil(t, source.Set("b", "2"))
+		assert.Nil(t, source.Set("a", "1"))
+
+		var buf bytes.Buffer
+		assert.Nil(t, ExportJSONLines(source, &buf))
+		assert.Equal(t, 2, strings.Count(buf.String(), "\n"))
+
+		// the export walks keys in order, "b" must still be the least recently used
+		assert.Nil(t, source.Set("c", "3"))
+		exists, err := source.Exists("b")
+		assert.Nil(t, err)
+		assert.False(t, exists)
+		exists, err = source.Exists("a")
+		assert.Nil(t, err)
+		assert.True(t, exists)
+	})
+
+	t.Run("ImportSkipsExpiredEntries", func(t *testing.T) {
+		target, err := NewMemoryClient(MemoryOptions{})
+		assert.Nil(t, err)
+		defer target.Close()
+
+		snapshot := `{"key":"old","value":"dmFsdWU=","expiresAt":1}` + "\n" + `{"key":"new","value":"dmFsdWU="}` + "\n"
+		assert.Nil(t, ImportJSONLines(target, strings.NewReader(snapshot)))
+
+		keys, err := target.Keys("")
+		assert.Nil(t, err)
+		assert.Equal(t, []string{"new"}, keys)
+	})
+
+	t.Run("ImportRejectsInvalidLines", func(t *testing.T) {
+		target, err := NewMemoryClient(MemoryOptions{})
+		assert.Nil(t, err)
+		defer target.Close()
+
+		err = ImportJSONLines(target, strings.NewReader("{\"key\":\"a\",\"value\":\"MQ==\"}\nnot json\n"))
+		assert.NotNil(t, err)
+		assert.Contains(t, err.Error(), "line 2")
+	})
+
+	t.Run("NamespaceBackupOnlyCoversItsKeys", func(t *testing.T) {
+		source, err := NewInMemoryBadgerClient()
+		assert.Nil(t, err)
+		defer source.Close()
+
+		_, err = source.Namespace("dionysios").SetMany(items, 0)
+		assert.Nil(t, err)
+		assert.Nil(t, source.Set("alexandros:λόγος", "word"))
+
+		var buf bytes.Buffer
+		_, err = source.Namespace("dionysios").Backup(&buf, 0)
+		assert.Nil(t, err)
+
+		target, err := NewInMemoryBadgerClient()
+		assert.Nil(t, err)
+		defer target.Close()
+
+		assert.Nil(t, target.Namespace("warm").Restore(&buf))
+		keys, err := target.Keys("")
+		assert.Nil(t, err)
+		assert.Equal(t, 3, len(keys))
+		for _, key := range keys {
+			assert.True(t, strings.HasPrefix(key, "warm:"))
+		}
+
+		_, err = target.Read("alexandros:λόγος")
+		assert.True(t, errors.Is(err, ErrNotFound))
+	})
+}
@@ -0,0 +1,72 @@
+package archytas
+
+// Stats is a point-in-time snapshot of a cache's counters. Fields a backend cannot
+// report stay zero: LSMSize, VLogSize and GCRuns are badger-only, Entries and Bytes are
+// only tracked by the in-memory backend. Namespaces report the stats of the cache they
+// live in, since they share its storage.
+type Stats struct {
+	Hits        uint64
+	Misses      uint64
+	Evictions   uint64
+	Expirations uint64
+	Entries     int64
+	Bytes       int64
+	LSMSize     int64
+	VLogSize    int64
+	GCRuns      uint64
+}
+
+func (s Stats) HitRatio() float64 {
+	total := s.Hits + s.Misses
+	if total == 0 {
+		return 0
+	}
+
+	return float64(s.Hits) / float64(total)
+}
+
+func (b *Badger) Stats() Stats {
+	lsm, vlog := b.db.Size()
+	return Stats{
+		Hits:     b.hits.Load(),
+		Misses:   b.misses.Load(),
+		LSMSize:  lsm,
+		VLogSize: vlog,
+		GCRuns:   b.gcRuns.Load(),
+	}
+}
+
+func (m *Memory) Stats() Stats {
+	m.mu.Lock()
+	entries, bytes := len(m.entries), m.bytes
+	m.mu.Unlock()
+
+	return Stats{
+		Hits:        m.hits.Load(),
+		Misses:      m.misses.Load(),
+		Evictions:   m.evictions.Load(),
+		Expirations: m.expirations.Load(),
+		Entries:     int64(entries),
+		Bytes:       bytes,
+	}
+}
+
+// Stats of a Tiered cache count a hit in either tier as a hit and only a miss in L2 as
+// a miss; use TierStats for the per-tier breakdown. Entries and Bytes add up what both
+// tiers hold in memory, which with a Badger L2 is the L1 alone.
+func (t *Tiered) Stats() Stats {
+	l1 := t.l1.Stats()
+	stats := t.l2.Stats()
+	stats.Hits = t.l1Hits.Load() + t.l2Hits.Load()
+	stats.Misses = t.l2Misses.Load()
+	stats.Evictions += l1.Evictions
+	stats.Expirations += l1.Expirations
+	stats.Entries += l1.Entries
+	stats.Bytes += l1.Bytes
+
+	return stats
+}
+
+func (n *namespaced) Stats() Stats {
+	return n.client.Stats()
+}
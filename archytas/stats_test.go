@@ -0,0 +1,92 @@
+package archytas
+
+import (
+	"bytes"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStats(t *testing.T) {
+	t.Run("BadgerCountsHitsAndMisses", func(t *testing.T) {
+		testClient, err := NewInMemoryBadgerClient()
+		assert.Nil(t, err)
+		defer testClient.Close()
+
+		assert.Nil(t, testClient.Set("a", "1"))
+		_, _ = testClient.Read("a")
+		_, _ = testClient.Read("b")
+		_, _ = testClient.ReadMany([]string{"a", "c"})
+
+		sut := testClient.Stats()
+		assert.Equal(t, uint64(2), sut.Hits)
+		assert.Equal(t, uint64(2), sut.Misses)
+		assert.Equal(t, 0.5, sut.HitRatio())
+	})
+
+	t.Run("MemoryCountsEvictions", func(t *testing.T) {
+		testClient, err := NewMemoryClient(MemoryOptions{MaxEntries: 1})
+		assert.Nil(t, err)
+		defer testClient.Close()
+
+		assert.Nil(t, testClient.Set("a", "1"))
+		assert.Nil(t, testClient.Set("b", "22"))
+		_, _ = testClient.Read("b")
+
+		sut := testClient.Namespace("ignored").Stats()
+		assert.Equal(t, uint64(1), sut.Hits)
+		assert.Equal(t, uint64(1), sut.Evictions)
+		assert.Equal(t, int64(1), sut.Entries)
+		assert.Equal(t, int64(3), sut.Bytes)
+	})
+
+	t.Run("TieredCombinesTiers", func(t *testing.T) {
+		l2, err := NewInMemoryBadgerClient()
+		assert.Nil(t, err)
+		testClient, err := NewTieredClient(l2, MemoryOptions{})
+		assert.Nil(t, err)
+		defer testClient.Close()
+
+		assert.Nil(t, l2.Set("a", "1"))
+		_, _ = testClient.Read("a")
+		_, _ = testClient.Read("a")
+		_, _ = testClient.Read("b")
+
+		sut := testClient.Stats()
+		assert.Equal(t, uint64(2), sut.Hits)
+		assert.Equal(t, uint64(1), sut.Misses)
+		assert.Equal(t, int64(1), sut.Entries)
+		assert.Equal(t, int64(2), sut.Bytes)
+	})
+
+	t.Run("MetricsHandler", func(t *testing.T) {
+		memory, err := NewMemoryClient(MemoryOptions{})
+		assert.Nil(t, err)
+		defer memory.Close()
+		l2, err := NewInMemoryBadgerClient()
+		assert.Nil(t, err)
+		tiered, err := NewTieredClient(l2, MemoryOptions{})
+		assert.Nil(t, err)
+		defer tiered.Close()
+
+		assert.Nil(t, memory.Set("a", "1"))
+		_, _ = memory.Read("a")
+		_, _ = tiered.Read("missing")
+
+		rec := httptest.NewRecorder()
+		MetricsHandler(map[string]Client{"dionysios": memory, "alexandros": tiered}).ServeHTTP(rec, httptest.NewRequest("GET", "/metrics", nil))
+
+		body := rec.Body.String()
+		assert.Contains(t, rec.Header().Get("Content-Type"), "text/plain")
+		assert.Contains(t, body, "# TYPE archytas_cache_hits_total counter")
+		assert.Contains(t, body, `archytas_cache_hits_total{cache="dionysios"} 1`)
+		assert.Contains(t, body, `archytas_cache_misses_total{cache="alexandros"} 1`)
+		assert.Contains(t, body, `archytas_cache_tier_misses_total{cache="alexandros",tier="l1"} 1`)
+		assert.NotContains(t, body, `archytas_cache_tier_misses_total{cache="dionysios"`)
+
+		var buf bytes.Buffer
+		assert.Nil(t, WriteMetrics(&buf, map[string]Client{}))
+		assert.Contains(t, buf.String(), "# HELP archytas_cache_hits_total")
+	})
+}
@@ -0,0 +1,253 @@
+package archytas
+
+import (
+	"errors"
+	"hash/fnv"
+	"slices"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// tieredKeyLocks is the number of lock stripes that order writes and L1 fills
+// of the same key.
+const tieredKeyLocks = 64
+
+type TierStats struct {
+	L1Hits   uint64
+	L1Misses uint64
+	L2Hits   uint64
+	L2Misses uint64
+}
+
+// Tiered keeps a bounded Memory tier (L1) in front of another Client (L2, normally
+// Badger). Writes go through to both tiers, reads fill L1 from L2 with the remaining
+// ttl of the L2 entry. L2 is authoritative for listing keys. Close closes both tiers.
+//
+// Every write and every fill after an L1 miss holds the lock of its key across
+// both tiers, so L1 never ends up with a value that L2 has already replaced.
+type Tiered struct {
+	l1 *Memory
+	l2 Client
+
+	// mu is held exclusively by DeletePrefix, which cannot lock every key it removes.
+	mu       sync.RWMutex
+	keyLocks [tieredKeyLocks]sync.Mutex
+
+	l1Hits   atomic.Uint64
+	l1Misses atomic.Uint64
+	l2Hits   atomic.Uint64
+	l2Misses atomic.Uint64
+}
+
+func NewTieredClient(l2 Client, l1Options MemoryOptions) (Client, error) {
+	l1, err := newMemory(l1Options)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Tiered{l1: l1, l2: l2}, nil
+}
+
+func (t *Tiered) TierStats() TierStats {
+	return TierStats{
+		L1Hits:   t.l1Hits.Load(),
+		L1Misses: t.l1Misses.Load(),
+		L2Hits:   t.l2Hits.Load(),
+		L2Misses: t.l2Misses.Load(),
+	}
+}
+
+func (t *Tiered) Close() error {
+	return errors.Join(t.l1.Close(), t.l2.Close())
+}
+
+func (t *Tiered) Set(key, value string) error {
+	return t.SetWithTTL(key, value, 0)
+}
+
+func (t *Tiered) SetWithTTL(key, value string, ttl time.Duration) error {
+	unlock := t.lock(key)
+	defer unlock()
+
+	var err error
+	if ttl > 0 {
+		err = t.l2.SetWithTTL(key, value, ttl)
+	} else {
+		err = t.l2.Set(key, value)
+	}
+	if err != nil {
+		// never leave an L1 entry that is newer than what L2 holds
+		t.l1.DeleteMany([]string{key})
+		return err
+	}
+
+	t.fill(key, []byte(value), ttl)
+	return nil
+}
+
+func (t *Tiered) Read(key string) ([]byte, error) {
+	value, _, err := t.ReadWithTTL(key)
+	return value, err
+}
+
+func (t *Tiered) ReadWithTTL(key string) ([]byte, time.Duration, error) {
+	value, ttl, err := t.l1.ReadWithTTL(key)
+	if err == nil {
+		t.l1Hits.Add(1)
+		return value, ttl, nil
+	}
+	t.l1Misses.Add(1)
+
+	return t.readThrough(key)
+}
+
+// readThrough reads key from L2 and fills L1 with it while holding the key lock,
+// so a concurrent write cannot land between the two.
+func (t *Tiered) readThrough(key string) ([]byte, time.Duration, error) {
+	unlock := t.lock(key)
+	defer unlock()
+
+	value, ttl, err := t.l2.ReadWithTTL(key)
+	if err != nil {
+		if errors.Is(err, ErrNotFound) {
+			t.l2Misses.Add(1)
+		}
+		return nil, 0, err
+	}
+	t.l2Hits.Add(1)
+
+	t.fill(key, value, ttl)
+	return value, ttl, nil
+}
+
+func (t *Tiered) Exists(key string) (bool, error) {
+	if exists, _ := t.l1.Exists(key); exists {
+		return true, nil
+	}
+
+	return t.l2.Exists(key)
+}
+
+func (t *Tiered) SetMany(items map[string]string, ttl time.Duration) (*BatchResult, error) {
+	keys := make([]string, 0, len(items))
+	for key := range items {
+		keys = append(keys, key)
+	}
+	unlock := t.lock(keys...)
+	defer unlock()
+
+	result, err := t.l2.SetMany(items, ttl)
+	if err != nil {
+		t.l1.DeleteMany(keys)
+		return nil, err
+	}
+
+	for key, value := range items {
+		if _, failed := result.Errors[key]; failed {
+			t.l1.DeleteMany([]string{key})
+			continue
+		}
+		t.fill(key, []byte(value), ttl)
+	}
+
+	return result, nil
+}
+
+func (t *Tiered) ReadMany(keys []string) (*BatchResult, error) {
+	result, err := t.l1.ReadMany(keys)
+	if err != nil {
+		return nil, err
+	}
+	t.l1Hits.Add(uint64(len(result.Values)))
+	t.l1Misses.Add(uint64(len(result.Errors)))
+
+	for _, key := range result.Failed() {
+		value, _, err := t.readThrough(key)
+		if err != nil {
+			result.Errors[key] = err
+			continue
+		}
+
+		delete(result.Errors, key)
+		result.Values[key] = value
+	}
+
+	return result, nil
+}
+
+// DeleteMany removes the keys from L2 first; L1 is cleared afterwards even when
+// that fails, so it never keeps a key that might be gone from L2.
+func (t *Tiered) DeleteMany(keys []string) (*BatchResult, error) {
+	unlock := t.lock(keys...)
+	defer unlock()
+
+	result, err := t.l2.DeleteMany(keys)
+	if _, l1Err := t.l1.DeleteMany(keys); l1Err != nil && err == nil {
+		return nil, l1Err
+	}
+
+	return result, err
+}
+
+func (t *Tiered) Keys(prefix string) ([]string, error) {
+	return t.l2.Keys(prefix)
+}
+
+func (t *Tiered) Scan(prefix string, fn func(key string, value []byte) error) error {
+	return t.l2.Scan(prefix, fn)
+}
+
+func (t *Tiered) scanWithTTL(prefix string, fn func(key string, value []byte, ttl time.Duration) error) error {
+	return scanWithTTL(t.l2, prefix, fn)
+}
+
+func (t *Tiered) DeletePrefix(prefix string) (int, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	deleted, err := t.l2.DeletePrefix(prefix)
+	if _, l1Err := t.l1.DeletePrefix(prefix); l1Err != nil && err == nil {
+		return 0, l1Err
+	}
+
+	return deleted, err
+}
+
+func (t *Tiered) Namespace(name string) Client {
+	return newNamespace(t, name)
+}
+
+// lock takes the stripes of keys in a fixed order, so callers locking several
+// keys cannot deadlock each other, and returns the matching unlock.
+func (t *Tiered) lock(keys ...string) func() {
+	stripes := make([]int, 0, len(keys))
+	for _, key := range keys {
+		h := fnv.New32a()
+		h.Write([]byte(key))
+		stripes = append(stripes, int(h.Sum32()%tieredKeyLocks))
+	}
+	slices.Sort(stripes)
+	stripes = slices.Compact(stripes)
+
+	t.mu.RLock()
+	for _, stripe := range stripes {
+		t.keyLocks[stripe].Lock()
+	}
+
+	return func() {
+		for _, stripe := range stripes {
+			t.keyLocks[stripe].Unlock()
+		}
+		t.mu.RUnlock()
+	}
+}
+
+// fill stores a value in L1. Failing to do so (e.g. the value is larger than the
+// L1 bound) only costs a future L2 read, but any older value still in L1 must go
+// so it is not served instead of the one in L2.
+func (t *Tiered) fill(key string, value []byte, ttl time.Duration) {
+	if err := t.l1.SetWithTTL(key, string(value), ttl); err != nil {
+		t.l1.DeleteMany([]string{key})
+	}
+}
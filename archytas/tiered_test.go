@@ -0,0 +1,196 @@
+package archytas
+
+import (
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTieredClient(t *testing.T) {
+	key := "λόγος"
+	value := "word"
+
+	newTiered := func(t *testing.T) (*Tiered, Client) {
+		l2, err := NewInMemoryBadgerClient()
+		assert.Nil(t, err)
+		testClient, err := NewTieredClient(l2, MemoryOptions{MaxEntries: 10})
+		assert.Nil(t, err)
+		return testClient.(*Tiered), l2
+	}
+
+	t.Run("WriteThrough", func(t *testing.T) {
+		testClient, l2 := newTiered(t)
+		defer testClient.Close()
+
+		err := testClient.Set(key, value)
+		assert.Nil(t, err)
+
+		stored, err := l2.Read(key)
+		assert.Nil(t, err)
+		assert.Equal(t, value, string(stored))
+
+		sut, err := testClient.Read(key)
+		assert.Nil(t, err)
+		assert.Equal(t, value, string(sut))
+		assert.Equal(t, TierStats{L1Hits: 1}, testClient.TierStats())
+	})
+
+	t.Run("FillsL1FromL2WithRemainingTTL", func(t *testing.T) {
+		testClient, l2 := newTiered(t)
+		defer testClient.Close()
+
+		err := l2.SetWithTTL(key, value, time.Hour)
+		assert.Nil(t, err)
+
+		sut, err := testClient.Read(key)
+		assert.Nil(t, err)
+		assert.Equal(t, value, string(sut))
+
+		_, ttl, err := testClient.l1.ReadWithTTL(key)
+		assert.Nil(t, err)
+		assert.True(t, ttl > 59*time.Minute)
+		assert.True(t, ttl <= time.Hour)
+
+		_, err = testClient.Read(key)
+		assert.Nil(t, err)
+		assert.Equal(t, TierStats{L1Hits: 1, L1Misses: 1, L2Hits: 1}, testClient.TierStats())
+	})
+
+	t.Run("MissInBothTiers", func(t *testing.T) {
+		testClient, _ := newTiered(t)
+		defer testClient.Close()
+
+		_, err := testClient.Read(key)
+		assert.True(t, errors.Is(err, ErrNotFound))
+		assert.Equal(t, TierStats{L1Misses: 1, L2Misses: 1}, testClient.TierStats())
+	})
+
+	t.Run("ReadManyMixesTiers", func(t *testing.T) {
+		testClient, l2 := newTiered(t)
+		defer testClient.Close()
+
+		assert.Nil(t, testClient.Set("a", "1"))
+		assert.Nil(t, l2.Set("b", "2"))
+
+		sut, err := testClient.ReadMany([]string{"a", "b", "c"})
+		assert.Nil(t, err)
+		assert.Equal(t, map[string][]byte{"a": []byte("1"), "b": []byte("2")}, sut.Values)
+		assert.Equal(t, []string{"c"}, sut.Failed())
+		assert.Equal(t, TierStats{L1Hits: 1, L1Misses: 2, L2Hits: 1, L2Misses: 1}, testClient.TierStats())
+	})
+
+	t.Run("DeleteRemovesFromBothTiers", func(t *testing.T) {
+		testClient, l2 := newTiered(t)
+		defer testClient.Close()
+
+		assert.Nil(t, testClient.Set(key, value))
+		_, err := testClient.DeleteMany([]string{key})
+		assert.Nil(t, err)
+
+		exists, err := testClient.Exists(key)
+		assert.Nil(t, err)
+		assert.False(t, exists)
+		exists, err = l2.Exists(key)
+		assert.Nil(t, err)
+		assert.False(t, exists)
+	})
+
+	t.Run("OversizedOverwriteDropsL1", func(t *testing.T) {
+		l2, err := NewInMemoryBadgerClient()
+		assert.Nil(t, err)
+		testClient, err := NewTieredClient(l2, MemoryOptions{MaxBytes: 32})
+		assert.Nil(t, err)
+		defer testClient.Close()
+
+		assert.Nil(t, testClient.Set(key, value))
+		large := "a value that no longer fits into the first tier"
+		assert.Nil(t, testClient.Set(key, large))
+
+		sut, err := testClient.Read(key)
+		assert.Nil(t, err)
+		assert.Equal(t, large, string(sut))
+	})
+
+	t.Run("ConcurrentWritesKeepTiersInSync", func(t *testing.T) {
+		badgerClient, err := NewInMemoryBadgerClient()
+		assert.Nil(t, err)
+		l2 := slowClient{Client: badgerClient}
+		tiered, err := NewTieredClient(l2, MemoryOptions{MaxEntries: 10})
+		assert.Nil(t, err)
+		testClient := tiered.(*Tiered)
+		defer testClient.Close()
+
+		keys := []string{"λόγος", "θεός"}
+		for round := 0; round < 20; round++ {
+			var wg sync.WaitGroup
+			for w := 0; w < 4; w++ {
+				wg.Add(2)
+				go func(w int) {
+					defer wg.Done()
+					for i := 0; i < 20; i++ {
+						key := keys[i%len(keys)]
+						switch i % 4 {
+						case 0:
+							testClient.DeleteMany([]string{key})
+						case 1:
+							testClient.SetMany(map[string]string{key: fmt.Sprintf("%d-%d", w, i)}, 0)
+						default:
+							testClient.Set(key, fmt.Sprintf("%d-%d", w, i))
+						}
+					}
+				}(w)
+				go func() {
+					defer wg.Done()
+					for i := 0; i < 20; i++ {
+						testClient.Read(keys[i%len(keys)])
+					}
+				}()
+			}
+			wg.Wait()
+
+			for _, key := range keys {
+				cached, _, l1Err := testClient.l1.ReadWithTTL(key)
+				if l1Err != nil {
+					continue
+				}
+				stored, err := l2.Read(key)
+				assert.Nil(t, err, "L1 holds %q but L2 does not", key)
+				assert.Equal(t, string(stored), string(cached))
+			}
+		}
+	})
+}
+
+// slowClient widens the window between the L2 and the L1 step of Tiered.
+type slowClient struct {
+	Client
+}
+
+func (c slowClient) pause() {
+	time.Sleep(time.Duration(rand.IntN(200)) * time.Microsecond)
+}
+
+func (c slowClient) Set(key, value string) error {
+	defer c.pause()
+	return c.Client.Set(key, value)
+}
+
+func (c slowClient) SetMany(items map[string]string, ttl time.Duration) (*BatchResult, error) {
+	defer c.pause()
+	return c.Client.SetMany(items, ttl)
+}
+
+func (c slowClient) ReadWithTTL(key string) ([]byte, time.Duration, error) {
+	defer c.pause()
+	return c.Client.ReadWithTTL(key)
+}
+
+func (c slowClient) DeleteMany(keys []string) (*BatchResult, error) {
+	defer c.pause()
+	return c.Client.DeleteMany(keys)
+}
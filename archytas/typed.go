@@ -0,0 +1,78 @@
+package archytas
+
+import (
+	"reflect"
+	"time"
+)
+
+type TypedCache[T any] struct {
+	client Client
+	codec  Codec
+}
+
+func NewTypedCache[T any](client Client, codec Codec) *TypedCache[T] {
+	if codec == nil {
+		codec = JSONCodec{}
+	}
+
+	return &TypedCache[T]{client: client, codec: codec}
+}
+
+func (t *TypedCache[T]) Client() Client {
+	return t.client
+}
+
+func (t *TypedCache[T]) Set(key string, value T) error {
+	data, err := t.encode(key, value)
+	if err != nil {
+		return err
+	}
+
+	return t.client.Set(key, string(data))
+}
+
+func (t *TypedCache[T]) SetWithTTL(key string, value T, ttl time.Duration) error {
+	data, err := t.encode(key, value)
+	if err != nil {
+		return err
+	}
+
+	return t.client.SetWithTTL(key, string(data), ttl)
+}
+
+func (t *TypedCache[T]) Read(key string) (T, error) {
+	var zero T
+	data, err := t.client.Read(key)
+	if err != nil {
+		return zero, err
+	}
+
+	return t.decode(key, data)
+}
+
+func (t *TypedCache[T]) encode(key string, value T) ([]byte, error) {
+	data, err := t.codec.Marshal(value)
+	if err != nil {
+		return nil, &CodecError{Codec: t.codec.Name(), Op: "marshal", Key: key, Err: err}
+	}
+
+	return data, nil
+}
+
+func (t *TypedCache[T]) decode(key string, data []byte) (T, error) {
+	var value T
+
+	// pointer types (such as proto messages) need an allocated target to decode into
+	target := any(&value)
+	if rt := reflect.TypeOf(value); rt != nil && rt.Kind() == reflect.Ptr {
+		reflect.ValueOf(&value).Elem().Set(reflect.New(rt.Elem()))
+		target = value
+	}
+
+	if err := t.codec.Unmarshal(data, target); err != nil {
+		var zero T
+		return zero, &CodecError{Codec: t.codec.Name(), Op: "unmarshal", Key: key, Err: err}
+	}
+
+	return value, nil
+}
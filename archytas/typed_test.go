@@ -0,0 +1,101 @@
+package archytas
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+type testWord struct {
+	Greek       string   `json:"greek"`
+	Translation []string `json:"translation"`
+}
+
+func TestTypedCache(t *testing.T) {
+	key := "λόγος"
+	word := testWord{Greek: "λόγος", Translation: []string{"word", "reason"}}
+
+	t.Run("JSONRoundTrip", func(t *testing.T) {
+		testClient, err := NewInMemoryBadgerClient()
+		assert.Nil(t, err)
+		defer testClient.Close()
+
+		cache := NewTypedCache[testWord](testClient, JSONCodec{})
+		err = cache.Set(key, word)
+		assert.Nil(t, err)
+
+		sut, err := cache.Read(key)
+		assert.Nil(t, err)
+		assert.Equal(t, word, sut)
+	})
+
+	t.Run("GobRoundTripWithTTL", func(t *testing.T) {
+		testClient, err := NewInMemoryBadgerClient()
+		assert.Nil(t, err)
+		defer testClient.Close()
+
+		cache := NewTypedCache[*testWord](testClient, GobCodec{})
+		err = cache.SetWithTTL(key, &word, time.Minute)
+		assert.Nil(t, err)
+
+		sut, err := cache.Read(key)
+		assert.Nil(t, err)
+		assert.Equal(t, &word, sut)
+	})
+
+	t.Run("ProtoRoundTrip", func(t *testing.T) {
+		testClient, err := NewInMemoryBadgerClient()
+		assert.Nil(t, err)
+		defer testClient.Close()
+
+		cache := NewTypedCache[*wrapperspb.StringValue](testClient, ProtoCodec{})
+		err = cache.Set(key, wrapperspb.String("λόγος"))
+		assert.Nil(t, err)
+
+		sut, err := cache.Read(key)
+		assert.Nil(t, err)
+		assert.Equal(t, "λόγος", sut.GetValue())
+	})
+
+	t.Run("CodecErrorOnCorruptValue", func(t *testing.T) {
+		testClient, err := NewInMemoryBadgerClient()
+		assert.Nil(t, err)
+		defer testClient.Close()
+
+		err = testClient.Set(key, "{not json")
+		assert.Nil(t, err)
+
+		cache := NewTypedCache[testWord](testClient, JSONCodec{})
+		_, err = cache.Read(key)
+		var codecErr *CodecError
+		assert.True(t, errors.As(err, &codecErr))
+		assert.Equal(t, "unmarshal", codecErr.Op)
+	})
+
+	t.Run("MissIsNotACodecError", func(t *testing.T) {
+		testClient, err := NewInMemoryBadgerClient()
+		assert.Nil(t, err)
+		defer testClient.Close()
+
+		cache := NewTypedCache[testWord](testClient, nil)
+		_, err = cache.Read(key)
+		assert.True(t, errors.Is(err, ErrNotFound))
+		var codecErr *CodecError
+		assert.False(t, errors.As(err, &codecErr))
+	})
+
+	t.Run("ProtoCodecRejectsNonMessage", func(t *testing.T) {
+		testClient, err := NewInMemoryBadgerClient()
+		assert.Nil(t, err)
+		defer testClient.Close()
+
+		cache := NewTypedCache[testWord](testClient, ProtoCodec{})
+		err = cache.Set(key, word)
+		var codecErr *CodecError
+		assert.True(t, errors.As(err, &codecErr))
+		assert.Equal(t, "marshal", codecErr.Op)
+	})
+}
@@ -0,0 +1,87 @@
+package aristoteles
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"sort"
+
+	"github.com/elastic/go-elasticsearch/v9/esapi"
+	"github.com/odysseia-greek/agora/aristoteles/models"
+)
+
+// UpdateAliases applies all actions in a single _aliases call, so either every
+// action is applied or none of them are.
+func (i *IndexImpl) UpdateAliases(ctx context.Context, actions ...models.AliasAction) (bool, error) {
+	if len(actions) == 0 {
+		return false, errors.New("no alias actions given")
+	}
+
+	body, err := toJSONReader(map[string]interface{}{"actions": actions})
+	if err != nil {
+		return false, err
+	}
+
+	jsonBody, err := perform(ctx, i.es, esapi.IndicesUpdateAliasesRequest{Body: body}, "update aliases")
+	if err != nil {
+		return false, err
+	}
+
+	return parseAcknowledged(jsonBody)
+}
+
+func (i *IndexImpl) AddAlias(ctx context.Context, index, alias string) (bool, error) {
+	return i.UpdateAliases(ctx, models.AddAliasAction(index, alias))
+}
+
+func (i *IndexImpl) RemoveAlias(ctx context.Context, index, alias string) (bool, error) {
+	return i.UpdateAliases(ctx, models.RemoveAliasAction(index, alias))
+}
+
+// SwapAlias moves alias from one index to another atomically.
+func (i *IndexImpl) SwapAlias(ctx context.Context, alias, from, to string) (bool, error) {
+	return i.UpdateAliases(ctx,
+		models.RemoveAliasAction(from, alias),
+		models.AddAliasAction(to, alias),
+	)
+}
+
+// GetAlias returns the sorted names of the indices the alias points to.
+func (i *IndexImpl) GetAlias(ctx context.Context, alias string) ([]string, error) {
+	jsonBody, err := perform(ctx, i.es, esapi.IndicesGetAliasRequest{Name: []string{alias}}, "get alias")
+	if err != nil {
+		return nil, err
+	}
+
+	var indices map[string]json.RawMessage
+	if err := json.Unmarshal(jsonBody, &indices); err != nil {
+		return nil, err
+	}
+
+	names := make([]string, 0, len(indices))
+	for name := range indices {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	return names, nil
+}
+
+func (i *IndexImpl) Rollover(ctx context.Context, alias string, conditions models.RolloverConditions) (*models.RolloverResult, error) {
+	body, err := toJSONReader(map[string]interface{}{"conditions": conditions})
+	if err != nil {
+		return nil, err
+	}
+
+	jsonBody, err := perform(ctx, i.es, esapi.IndicesRolloverRequest{Alias: alias, Body: body}, "rollover")
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := models.UnmarshalRolloverResult(jsonBody)
+	if err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
@@ -0,0 +1,191 @@
+package aristoteles
+
+import (
+	"context"
+
+	"github.com/elastic/go-elasticsearch/v9/esapi"
+	"github.com/odysseia-greek/agora/aristoteles/models"
+)
+
+const (
+	GreekAnalyzer                   = "greek_analyzer"
+	GreekAutocompleteAnalyzer       = "greek_autocomplete"
+	GreekAutocompleteSearchAnalyzer = "greek_autocomplete_search"
+)
+
+// GreekAnalyzerOptions configures the analyzers TextIndexWithAnalyzer adds to
+// an index. ICUFolding requires the analysis-icu plugin on the cluster.
+type GreekAnalyzerOptions struct {
+	// ICUFolding uses the icu_tokenizer and icu_folding, which strips accents,
+	// breathings and iota subscripts from precomposed polytonic characters.
+	ICUFolding bool
+	// StripBreathingMarks removes combining and spacing psili/dasia before
+	// tokenizing. Precomposed characters are only affected after ICU folding.
+	StripBreathingMarks bool
+	// NormalizeElision removes the apostrophe variants marking elision, so
+	// ἀπ’ and ἀπ' both index as ἀπ.
+	NormalizeElision bool
+	StopWords        bool
+	Stemming         bool
+	// CharMappings are extra "from => to" rules applied before tokenizing.
+	CharMappings []string
+	// Autocomplete adds an edge-ngram analyzer pair for search-as-you-type.
+	Autocomplete *EdgeNGramOptions
+}
+
+type EdgeNGramOptions struct {
+	MinGram int
+	MaxGram int
+}
+
+// DefaultGreekAnalyzerOptions reproduces the analyzer TextIndex has always used.
+func DefaultGreekAnalyzerOptions() GreekAnalyzerOptions {
+	return GreekAnalyzerOptions{StopWords: true, Stemming: true}
+}
+
+// Analysis returns the "analysis" index settings for the configured analyzers.
+func (o GreekAnalyzerOptions) Analysis() map[string]interface{} {
+	filters := map[string]interface{}{}
+	charFilters := map[string]interface{}{}
+
+	var charFilterNames []string
+	if o.StripBreathingMarks {
+		charFilters["greek_breathing"] = map[string]interface{}{
+			"type": "mapping",
+			"mappings": []string{
+				"\\u0313=>", // combining comma above (psili)
+				"\\u0314=>", // combining reversed comma above (dasia)
+				"\\u0343=>", // combining greek koronis
+				"\\u1FBF=>", // greek psili
+				"\\u1FFE=>", // greek dasia
+			},
+		}
+		charFilterNames = append(charFilterNames, "greek_breathing")
+	}
+	if o.NormalizeElision {
+		charFilters["greek_elision"] = map[string]interface{}{
+			"type": "mapping",
+			"mappings": []string{
+				"\\u2019=>", // right single quotation mark
+				"\\u02BC=>", // modifier letter apostrophe
+				"\\u1FBD=>", // greek koronis
+				"'=>",
+			},
+		}
+		charFilterNames = append(charFilterNames, "greek_elision")
+	}
+	if len(o.CharMappings) > 0 {
+		charFilters["greek_custom_mapping"] = map[string]interface{}{
+			"type":     "mapping",
+			"mappings": o.CharMappings,
+		}
+		charFilterNames = append(charFilterNames, "greek_custom_mapping")
+	}
+
+	tokenizer := "standard"
+	base := []string{"lowercase"}
+	if o.ICUFolding {
+		tokenizer = "icu_tokenizer"
+		base = append(base, "icu_folding")
+	}
+
+	// the _greek_ list is unaccented lowercase with a final σ, so with folding on
+	// stop words are only matched after icu_folding has turned ὁ into ο
+	analyzerFilters := append([]string{}, base...)
+	if o.StopWords {
+		filters["greek_stop"] = map[string]interface{}{
+			"type":      "stop",
+			"stopwords": "_greek_",
+		}
+		analyzerFilters = append(analyzerFilters, "greek_stop")
+	}
+	if o.Stemming {
+		filters["greek_stemmer"] = map[string]interface{}{
+			"type":     "stemmer",
+			"language": "greek",
+		}
+		analyzerFilters = append(analyzerFilters, "greek_stemmer")
+	}
+
+	analyzers := map[string]interface{}{
+		GreekAnalyzer: customAnalyzer(tokenizer, charFilterNames, analyzerFilters),
+	}
+
+	if o.Autocomplete != nil {
+		filters["greek_edge_ngram"] = map[string]interface{}{
+			"type":     "edge_ngram",
+			"min_gram": o.Autocomplete.MinGram,
+			"max_gram": o.Autocomplete.MaxGram,
+		}
+		analyzers[GreekAutocompleteAnalyzer] = customAnalyzer(tokenizer, charFilterNames, append(append([]string{}, base...), "greek_edge_ngram"))
+		analyzers[GreekAutocompleteSearchAnalyzer] = customAnalyzer(tokenizer, charFilterNames, base)
+	}
+
+	analysis := map[string]interface{}{
+		"analyzer": analyzers,
+	}
+	if len(filters) > 0 {
+		analysis["filter"] = filters
+	}
+	if len(charFilters) > 0 {
+		analysis["char_filter"] = charFilters
+	}
+
+	return analysis
+}
+
+// GreekField returns the mapping for a text field analyzed with GreekAnalyzer,
+// with an "autocomplete" sub-field when Autocomplete is configured.
+func (o GreekAnalyzerOptions) GreekField() map[string]interface{} {
+	field := map[string]interface{}{
+		"type":     "text",
+		"analyzer": GreekAnalyzer,
+	}
+	if o.Autocomplete != nil {
+		field["fields"] = map[string]interface{}{
+			"autocomplete": map[string]interface{}{
+				"type":            "text",
+				"analyzer":        GreekAutocompleteAnalyzer,
+				"search_analyzer": GreekAutocompleteSearchAnalyzer,
+			},
+		}
+	}
+
+	return field
+}
+
+func customAnalyzer(tokenizer string, charFilters, filters []string) map[string]interface{} {
+	analyzer := map[string]interface{}{
+		"type":      "custom",
+		"tokenizer": tokenizer,
+		"filter":    filters,
+	}
+	if len(charFilters) > 0 {
+		analyzer["char_filter"] = charFilters
+	}
+
+	return analyzer
+}
+
+// Analyze runs text through an analyzer. index may be empty for built-in analyzers.
+func (i *IndexImpl) Analyze(ctx context.Context, index, analyzer, text string) ([]models.AnalyzeToken, error) {
+	body, err := toJSONReader(map[string]interface{}{
+		"analyzer": analyzer,
+		"text":     text,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	jsonBody, err := perform(ctx, i.es, esapi.IndicesAnalyzeRequest{Index: index, Body: body}, "analyze")
+	if err != nil {
+		return nil, err
+	}
+
+	response, err := models.UnmarshalAnalyzeResponse(jsonBody)
+	if err != nil {
+		return nil, err
+	}
+
+	return response.Tokens, nil
+}
@@ -0,0 +1,103 @@
+package aristoteles
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGreekAnalyzerOptions(t *testing.T) {
+	t.Run("DefaultMatchesTextIndex", func(t *testing.T) {
+		analysis, err := json.Marshal(DefaultGreekAnalyzerOptions().Analysis())
+		assert.Nil(t, err)
+		assert.JSONEq(t, `{
+			"analyzer": {
+				"greek_analyzer": {
+					"type": "custom",
+					"tokenizer": "standard",
+					"filter": ["lowercase", "greek_stop", "greek_stemmer"]
+				}
+			},
+			"filter": {
+				"greek_stop": {"type": "stop", "stopwords": "_greek_"},
+				"greek_stemmer": {"type": "stemmer", "language": "greek"}
+			}
+		}`, string(analysis))
+
+		index := NewBuilderImpl().TextIndex("text_policy")
+		greek := index["mappings"].(map[string]interface{})["properties"].(map[string]interface{})["greek"]
+		assert.Equal(t, map[string]interface{}{"type": "text", "analyzer": GreekAnalyzer}, greek)
+	})
+
+	t.Run("ICUFoldingWithCharFilters", func(t *testing.T) {
+		options := GreekAnalyzerOptions{
+			ICUFolding:          true,
+			StripBreathingMarks: true,
+			NormalizeElision:    true,
+			StopWords:           true,
+			CharMappings:        []string{"ϲ => σ"},
+		}
+		analysis := options.Analysis()
+
+		analyzer := analysis["analyzer"].(map[string]interface{})[GreekAnalyzer].(map[string]interface{})
+		assert.Equal(t, "icu_tokenizer", analyzer["tokenizer"])
+		// folding first, so polytonic articles like ὁ and τό reach the stop filter as ο and το
+		assert.Equal(t, []string{"lowercase", "icu_folding", "greek_stop"}, analyzer["filter"])
+		assert.Equal(t, []string{"greek_breathing", "greek_elision", "greek_custom_mapping"}, analyzer["char_filter"])
+
+		charFilters := analysis["char_filter"].(map[string]interface{})
+		assert.Len(t, charFilters, 3)
+		assert.NotContains(t, analysis["filter"], "greek_stemmer")
+
+		options.Stemming = true
+		analyzer = options.Analysis()["analyzer"].(map[string]interface{})[GreekAnalyzer].(map[string]interface{})
+		assert.Equal(t, []string{"lowercase", "icu_folding", "greek_stop", "greek_stemmer"}, analyzer["filter"])
+	})
+
+	t.Run("Autocomplete", func(t *testing.T) {
+		options := DefaultGreekAnalyzerOptions()
+		options.Autocomplete = &EdgeNGramOptions{MinGram: 2, MaxGram: 15}
+		analysis := options.Analysis()
+
+		analyzers := analysis["analyzer"].(map[string]interface{})
+		autocomplete := analyzers[GreekAutocompleteAnalyzer].(map[string]interface{})
+		search := analyzers[GreekAutocompleteSearchAnalyzer].(map[string]interface{})
+		assert.Equal(t, []string{"lowercase", "greek_edge_ngram"}, autocomplete["filter"])
+		assert.Equal(t, []string{"lowercase"}, search["filter"])
+
+		edgeNGram := analysis["filter"].(map[string]interface{})["greek_edge_ngram"].(map[string]interface{})
+		assert.Equal(t, 2, edgeNGram["min_gram"])
+		assert.Equal(t, 15, edgeNGram["max_gram"])
+
+		index := NewBuilderImpl().TextIndexWithAnalyzer("text_policy", options)
+		greek := index["mappings"].(map[string]interface{})["properties"].(map[string]interface{})["greek"].(map[string]interface{})
+		sub := greek["fields"].(map[string]interface{})["autocomplete"].(map[string]interface{})
+		assert.Equal(t, GreekAutocompleteSearchAnalyzer, sub["search_analyzer"])
+	})
+}
+
+func TestAnalyze(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Tokens", func(t *testing.T) {
+		testClient, err := NewMockClient("analyze", 200)
+		assert.Nil(t, err)
+
+		tokens, err := testClient.Index().Analyze(ctx, "text", GreekAnalyzer, "ἀπ’ αὐτοῦ")
+		assert.Nil(t, err)
+		assert.Len(t, tokens, 2)
+		assert.Equal(t, "απ", tokens[0].Token)
+		assert.Equal(t, 1, tokens[1].Position)
+	})
+
+	t.Run("UnknownAnalyzer", func(t *testing.T) {
+		testClient, err := NewMockClient("error", 400)
+		assert.Nil(t, err)
+
+		tokens, err := testClient.Index().Analyze(ctx, "text", "missing", "λόγος")
+		assert.NotNil(t, err)
+		assert.Nil(t, tokens)
+	})
+}
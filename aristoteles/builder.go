@@ -11,6 +11,10 @@ func NewBuilderImpl() *BuilderImpl {
 	return &BuilderImpl{}
 }
 
+func (b *BuilderImpl) Search() *SearchRequest {
+	return NewSearch()
+}
+
 func (b *BuilderImpl) MatchQuery(term, queryWord string) map[string]interface{} {
 	return map[string]interface{}{
 		"query": map[string]interface{}{
@@ -129,6 +133,10 @@ func (b *BuilderImpl) SearchAsYouTypeIndex(searchWord string) map[string]interfa
 }
 
 func (b *BuilderImpl) TextIndex(policyName string) map[string]interface{} {
+	return b.TextIndexWithAnalyzer(policyName, DefaultGreekAnalyzerOptions())
+}
+
+func (b *BuilderImpl) TextIndexWithAnalyzer(policyName string, analyzer GreekAnalyzerOptions) map[string]interface{} {
 	return map[string]interface{}{
 		"settings": map[string]interface{}{
 			"index": map[string]interface{}{
@@ -136,39 +144,14 @@ func (b *BuilderImpl) TextIndex(policyName string) map[string]interface{} {
 				"number_of_replicas": 1,
 				"lifecycle.name":     policyName,
 			},
-			"analysis": map[string]interface{}{
-				"analyzer": map[string]interface{}{
-					"greek_analyzer": map[string]interface{}{
-						"type":      "custom",
-						"tokenizer": "standard",
-						"filter": []string{
-							"lowercase",
-							"greek_stop",
-							"greek_stemmer",
-						},
-					},
-				},
-				"filter": map[string]interface{}{
-					"greek_stop": map[string]interface{}{
-						"type":      "stop",
-						"stopwords": "_greek_",
-					},
-					"greek_stemmer": map[string]interface{}{
-						"type":     "stemmer",
-						"language": "greek",
-					},
-				},
-			},
+			"analysis": analyzer.Analysis(),
 		},
 		"mappings": map[string]interface{}{
 			"properties": map[string]interface{}{
 				"author": map[string]interface{}{
 					"type": "keyword",
 				},
-				"greek": map[string]interface{}{
-					"type":     "text",
-					"analyzer": "greek_analyzer",
-				},
+				"greek": analyzer.GreekField(),
 				"translations": map[string]interface{}{
 					"type": "text",
 				},
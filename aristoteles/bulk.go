@@ -10,22 +10,39 @@ import (
 type BulkResponse struct {
 	Errors bool `json:"errors"`
 	Items  []struct {
-		Index struct {
-			ID     string `json:"_id"`
-			Result string `json:"result"`
-			Status int    `json:"status"`
-			Error  struct {
-				Type   string `json:"type"`
-				Reason string `json:"reason"`
-				Cause  struct {
-					Type   string `json:"type"`
-					Reason string `json:"reason"`
-				} `json:"caused_by"`
-			} `json:"error"`
-		} `json:"index"`
+		Index BulkIndexResult `json:"index"`
 	} `json:"items"`
 }
 
+type BulkIndexResult struct {
+	ID     string `json:"_id"`
+	Result string `json:"result"`
+	Status int    `json:"status"`
+	Error  struct {
+		Type   string `json:"type"`
+		Reason string `json:"reason"`
+		Cause  struct {
+			Type   string `json:"type"`
+			Reason string `json:"reason"`
+		} `json:"caused_by"`
+	} `json:"error"`
+}
+
+// Err returns the item error as a *BulkItemError so IsConflict and the other
+// predicates work on it, or nil when the item succeeded.
+func (r BulkIndexResult) Err() error {
+	if r.Error.Type == "" {
+		return nil
+	}
+
+	itemErr := &BulkItemError{Type: r.Error.Type, Reason: r.Error.Reason}
+	if r.Error.Cause.Type != "" {
+		itemErr.Cause = &ErrorCause{Type: r.Error.Cause.Type, Reason: r.Error.Cause.Reason}
+	}
+
+	return itemErr
+}
+
 func (d *DocumentImpl) Bulk(buf bytes.Buffer, index string) (*BulkResponse, error) {
 	return d.BulkWithContext(context.Background(), buf, index)
 }
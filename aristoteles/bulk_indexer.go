@@ -0,0 +1,455 @@
+package aristoteles
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/elastic/go-elasticsearch/v9"
+	"github.com/elastic/go-elasticsearch/v9/esapi"
+)
+
+const (
+	BulkIndex  = "index"
+	BulkCreate = "create"
+	BulkUpdate = "update"
+	BulkDelete = "delete"
+)
+
+var ErrBulkIndexerClosed = errors.New("bulk indexer is closed")
+
+// BulkItem is a single action queued on a BulkIndexer. Document is marshalled to JSON
+// (and wrapped in {"doc": ...} for updates); Body can be used instead to send a
+// pre-encoded source or a scripted update verbatim.
+type BulkItem struct {
+	Action     string
+	Index      string
+	DocumentID string
+	Document   interface{}
+	Body       []byte
+
+	OnSuccess func(ctx context.Context, item BulkItem, res BulkResponseItem)
+	OnFailure func(ctx context.Context, item BulkItem, res BulkResponseItem, err error)
+}
+
+type BulkResponseItem struct {
+	Index       string         `json:"_index"`
+	ID          string         `json:"_id"`
+	Version     int64          `json:"_version"`
+	Result      string         `json:"result"`
+	Status      int            `json:"status"`
+	SeqNo       int64          `json:"_seq_no"`
+	PrimaryTerm int64          `json:"_primary_term"`
+	Error       *BulkItemError `json:"error,omitempty"`
+}
+
+// BulkItemError is the error of a single bulk item; IsConflict and the other
+// predicates accept it like an *ElasticError.
+type BulkItemError = ErrorCause
+
+type BulkIndexerConfig struct {
+	// Index is used for items that do not set their own.
+	Index string
+	// NumWorkers is the number of concurrent flushers, 2 when zero.
+	NumWorkers int
+	// FlushBytes and FlushCount trigger a flush of a worker's buffer once either is
+	// reached; FlushInterval flushes whatever is buffered periodically. Defaults are
+	// 5MB, 1000 items and 30s.
+	FlushBytes    int
+	FlushCount    int
+	FlushInterval time.Duration
+	// MaxRetries bounds the retries of a request or of items rejected with 429 Too
+	// Many Requests; zero means 3 and a negative value disables retries.
+	// RetryBackoff defaults to an exponential backoff from 100ms, capped at 10s.
+	MaxRetries   int
+	RetryBackoff func(attempt int) time.Duration
+	// OnError is called for errors that affect a whole request rather than one item.
+	OnError func(ctx context.Context, err error)
+}
+
+type BulkIndexerStats struct {
+	NumAdded    uint64
+	NumFlushed  uint64
+	NumFailed   uint64
+	NumIndexed  uint64
+	NumCreated  uint64
+	NumUpdated  uint64
+	NumDeleted  uint64
+	NumRequests uint64
+	NumRetries  uint64
+}
+
+type BulkIndexer interface {
+	Add(ctx context.Context, item BulkItem) error
+	Close(ctx context.Context) error
+	Stats() BulkIndexerStats
+}
+
+type bulkIndexer struct {
+	es     *elasticsearch.Client
+	config BulkIndexerConfig
+	// ctx is cancelled when Close gives up, aborting requests and backoffs in flight.
+	ctx    context.Context
+	cancel context.CancelFunc
+
+	queue     chan queuedBulkItem
+	wg        sync.WaitGroup
+	closeOnce sync.Once
+	closed    bool
+	mu        sync.RWMutex
+
+	added    atomic.Uint64
+	flushed  atomic.Uint64
+	failed   atomic.Uint64
+	indexed  atomic.Uint64
+	created  atomic.Uint64
+	updated  atomic.Uint64
+	deleted  atomic.Uint64
+	requests atomic.Uint64
+	retries  atomic.Uint64
+}
+
+type queuedBulkItem struct {
+	ctx     context.Context
+	item    BulkItem
+	encoded []byte
+}
+
+func (d *DocumentImpl) NewBulkIndexer(config BulkIndexerConfig) (BulkIndexer, error) {
+	if config.NumWorkers <= 0 {
+		config.NumWorkers = 2
+	}
+	if config.FlushBytes <= 0 {
+		config.FlushBytes = 5 << 20
+	}
+	if config.FlushCount <= 0 {
+		config.FlushCount = 1000
+	}
+	if config.FlushInterval <= 0 {
+		config.FlushInterval = 30 * time.Second
+	}
+	switch {
+	case config.MaxRetries == 0:
+		config.MaxRetries = defaultBulkMaxRetries
+	case config.MaxRetries < 0:
+		config.MaxRetries = 0
+	}
+	if config.RetryBackoff == nil {
+		config.RetryBackoff = defaultBulkBackoff
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	bi := &bulkIndexer{
+		es:     d.es,
+		config: config,
+		queue:  make(chan queuedBulkItem, config.NumWorkers),
+		ctx:    ctx,
+		cancel: cancel,
+	}
+	for i := 0; i < config.NumWorkers; i++ {
+		bi.wg.Add(1)
+		go bi.work()
+	}
+
+	return bi, nil
+}
+
+func (bi *bulkIndexer) Add(ctx context.Context, item BulkItem) error {
+	encoded, err := encodeBulkItem(item, bi.config.Index)
+	if err != nil {
+		return err
+	}
+
+	bi.mu.RLock()
+	defer bi.mu.RUnlock()
+	if bi.closed {
+		return ErrBulkIndexerClosed
+	}
+
+	select {
+	case bi.queue <- queuedBulkItem{ctx: ctx, item: item, encoded: encoded}:
+		bi.added.Add(1)
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Close flushes everything still buffered and waits for the workers to finish. When
+// ctx is done first, requests in flight are cancelled, the items not yet sent fail
+// and Close still waits for the workers to stop before returning ctx.Err().
+func (bi *bulkIndexer) Close(ctx context.Context) error {
+	bi.closeOnce.Do(func() {
+		bi.mu.Lock()
+		bi.closed = true
+		close(bi.queue)
+		bi.mu.Unlock()
+	})
+
+	done := make(chan struct{})
+	go func() {
+		bi.wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		bi.cancel()
+		return nil
+	case <-ctx.Done():
+		bi.cancel()
+		<-done
+		return ctx.Err()
+	}
+}
+
+func (bi *bulkIndexer) Stats() BulkIndexerStats {
+	return BulkIndexerStats{
+		NumAdded:    bi.added.Load(),
+		NumFlushed:  bi.flushed.Load(),
+		NumFailed:   bi.failed.Load(),
+		NumIndexed:  bi.indexed.Load(),
+		NumCreated:  bi.created.Load(),
+		NumUpdated:  bi.updated.Load(),
+		NumDeleted:  bi.deleted.Load(),
+		NumRequests: bi.requests.Load(),
+		NumRetries:  bi.retries.Load(),
+	}
+}
+
+func (bi *bulkIndexer) work() {
+	defer bi.wg.Done()
+
+	ticker := time.NewTicker(bi.config.FlushInterval)
+	defer ticker.Stop()
+
+	var buffer []queuedBulkItem
+	size := 0
+	flush := func() {
+		if len(buffer) == 0 {
+			return
+		}
+		bi.flush(buffer)
+		buffer = nil
+		size = 0
+	}
+
+	for {
+		select {
+		case queued, ok := <-bi.queue:
+			if !ok {
+				flush()
+				return
+			}
+			if size > 0 && size+len(queued.encoded) > bi.config.FlushBytes {
+				flush()
+			}
+			buffer = append(buffer, queued)
+			size += len(queued.encoded)
+			if len(buffer) >= bi.config.FlushCount || size >= bi.config.FlushBytes {
+				flush()
+			}
+		case <-ticker.C:
+			flush()
+		}
+	}
+}
+
+func (bi *bulkIndexer) flush(items []queuedBulkItem) {
+	ctx := bi.ctx
+	pending := items
+
+	for attempt := 0; len(pending) > 0; attempt++ {
+		if attempt > 0 {
+			bi.retries.Add(uint64(len(pending)))
+			timer := time.NewTimer(bi.config.RetryBackoff(attempt - 1))
+			select {
+			case <-timer.C:
+			case <-ctx.Done():
+				timer.Stop()
+				for _, queued := range pending {
+					bi.fail(queued, BulkResponseItem{}, ctx.Err())
+				}
+				return
+			}
+		}
+
+		responses, err := bi.send(ctx, pending)
+		if err != nil {
+			var elasticErr *ElasticError
+			if errors.As(err, &elasticErr) && elasticErr.StatusCode == http.StatusTooManyRequests && attempt < bi.config.MaxRetries {
+				continue
+			}
+			if bi.config.OnError != nil {
+				bi.config.OnError(ctx, err)
+			}
+			for _, queued := range pending {
+				bi.fail(queued, BulkResponseItem{}, err)
+			}
+			return
+		}
+
+		var retry []queuedBulkItem
+		for i, queued := range pending {
+			res := responses[i]
+			switch {
+			case res.Status == http.StatusTooManyRequests && attempt < bi.config.MaxRetries:
+				retry = append(retry, queued)
+			case res.Status >= 300 || res.Error != nil:
+				var itemErr error = fmt.Errorf("bulk %s failed with status %d", queued.item.Action, res.Status)
+				if res.Error != nil {
+					itemErr = res.Error
+				}
+				bi.fail(queued, res, itemErr)
+			default:
+				bi.succeed(queued, res)
+			}
+		}
+		pending = retry
+	}
+}
+
+const (
+	defaultBulkMaxRetries = 3
+	maxBulkBackoff        = 10 * time.Second
+)
+
+func defaultBulkBackoff(attempt int) time.Duration {
+	// 100ms << 7 already exceeds the cap, a larger shift could overflow
+	if attempt > 7 {
+		return maxBulkBackoff
+	}
+
+	return min(100*time.Millisecond<<attempt, maxBulkBackoff)
+}
+
+func (bi *bulkIndexer) send(ctx context.Context, items []queuedBulkItem) ([]BulkResponseItem, error) {
+	var body bytes.Buffer
+	for _, queued := range items {
+		body.Write(queued.encoded)
+	}
+
+	bi.requests.Add(1)
+	opts := []func(*esapi.BulkRequest){bi.es.Bulk.WithContext(ctx)}
+	if bi.config.Index != "" {
+		opts = append(opts, bi.es.Bulk.WithIndex(bi.config.Index))
+	}
+	res, err := bi.es.Bulk(bytes.NewReader(body.Bytes()), opts...)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	jsonBody, err := io.ReadAll(res.Body)
+	if err != nil {
+		return nil, err
+	}
+	if res.IsError() {
+		return nil, newElasticErrorFromBody("bulk indexer flush", res, jsonBody)
+	}
+
+	var parsed struct {
+		Items []map[string]BulkResponseItem `json:"items"`
+	}
+	if err := json.Unmarshal(jsonBody, &parsed); err != nil {
+		return nil, err
+	}
+	if len(parsed.Items) != len(items) {
+		return nil, fmt.Errorf("bulk indexer flush: sent %d items but got %d results", len(items), len(parsed.Items))
+	}
+
+	responses := make([]BulkResponseItem, len(items))
+	for i, item := range parsed.Items {
+		for _, res := range item {
+			responses[i] = res
+		}
+	}
+
+	return responses, nil
+}
+
+func (bi *bulkIndexer) succeed(queued queuedBulkItem, res BulkResponseItem) {
+	bi.flushed.Add(1)
+	switch queued.item.Action {
+	case BulkCreate:
+		bi.created.Add(1)
+	case BulkUpdate:
+		bi.updated.Add(1)
+	case BulkDelete:
+		bi.deleted.Add(1)
+	default:
+		bi.indexed.Add(1)
+	}
+
+	if queued.item.OnSuccess != nil {
+		queued.item.OnSuccess(queued.ctx, queued.item, res)
+	}
+}
+
+func (bi *bulkIndexer) fail(queued queuedBulkItem, res BulkResponseItem, err error) {
+	bi.failed.Add(1)
+	if queued.item.OnFailure != nil {
+		queued.item.OnFailure(queued.ctx, queued.item, res, err)
+	}
+}
+
+func encodeBulkItem(item BulkItem, defaultIndex string) ([]byte, error) {
+	action := item.Action
+	if action == "" {
+		action = BulkIndex
+	}
+
+	meta := map[string]string{}
+	if item.Index != "" && item.Index != defaultIndex {
+		meta["_index"] = item.Index
+	} else if defaultIndex == "" {
+		return nil, fmt.Errorf("bulk %s: no index set on the item or the indexer", action)
+	}
+	if item.DocumentID != "" {
+		meta["_id"] = item.DocumentID
+	}
+
+	var buf bytes.Buffer
+	if err := json.NewEncoder(&buf).Encode(map[string]interface{}{action: meta}); err != nil {
+		return nil, err
+	}
+
+	switch action {
+	case BulkDelete:
+		if item.DocumentID == "" {
+			return nil, fmt.Errorf("bulk delete requires a document id")
+		}
+		return buf.Bytes(), nil
+	case BulkUpdate:
+		if item.DocumentID == "" {
+			return nil, fmt.Errorf("bulk update requires a document id")
+		}
+	case BulkIndex, BulkCreate:
+	default:
+		return nil, fmt.Errorf("unknown bulk action %q", action)
+	}
+
+	source := item.Body
+	if source == nil {
+		var document interface{} = item.Document
+		if action == BulkUpdate {
+			document = map[string]interface{}{"doc": item.Document}
+		}
+		encoded, err := json.Marshal(document)
+		if err != nil {
+			return nil, err
+		}
+		source = encoded
+	}
+
+	buf.Write(bytes.TrimSpace(source))
+	buf.WriteByte('\n')
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,207 @@
+package aristoteles
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBulkIndexer(t *testing.T) {
+	index := "dictionary"
+	noBackoff := func(attempt int) time.Duration { return time.Millisecond }
+
+	newIndexer := func(t *testing.T, files []string, statusCodes []int, config BulkIndexerConfig) BulkIndexer {
+		esClient, err := CreateMockClientWithStatusCodes(files, statusCodes)
+		assert.Nil(t, err)
+		document, err := NewDocumentImpl(esClient)
+		assert.Nil(t, err)
+
+		config.Index = index
+		config.NumWorkers = 1
+		config.RetryBackoff = noBackoff
+		indexer, err := document.NewBulkIndexer(config)
+		assert.Nil(t, err)
+		return indexer
+	}
+
+	t.Run("MixedActions", func(t *testing.T) {
+		indexer := newIndexer(t, []string{"bulkMixed"}, []int{200}, BulkIndexerConfig{FlushCount: 4})
+
+		var mu sync.Mutex
+		var succeeded []string
+		var failed []error
+		onSuccess := func(ctx context.Context, item BulkItem, res BulkResponseItem) {
+			mu.Lock()
+			defer mu.Unlock()
+			succeeded = append(succeeded, res.Result)
+		}
+		onFailure := func(ctx context.Context, item BulkItem, res BulkResponseItem, err error) {
+			mu.Lock()
+			defer mu.Unlock()
+			failed = append(failed, err)
+		}
+
+		ctx := context.Background()
+		word := map[string]string{"greek": "λόγος", "english": "word"}
+		assert.Nil(t, indexer.Add(ctx, BulkItem{Action: BulkIndex, DocumentID: "1", Document: word, OnSuccess: onSuccess, OnFailure: onFailure}))
+		assert.Nil(t, indexer.Add(ctx, BulkItem{Action: BulkCreate, DocumentID: "2", Document: word, OnSuccess: onSuccess, OnFailure: onFailure}))
+		assert.Nil(t, indexer.Add(ctx, BulkItem{Action: BulkUpdate, DocumentID: "3", Document: map[string]string{"english": "reason"}, OnSuccess: onSuccess, OnFailure: onFailure}))
+		assert.Nil(t, indexer.Add(ctx, BulkItem{Action: BulkDelete, DocumentID: "4", OnSuccess: onSuccess, OnFailure: onFailure}))
+		assert.Nil(t, indexer.Close(ctx))
+
+		assert.ElementsMatch(t, []string{"created", "updated", "deleted"}, succeeded)
+		assert.Equal(t, 1, len(failed))
+		var itemErr *BulkItemError
+		assert.True(t, errors.As(failed[0], &itemErr))
+		assert.Equal(t, "version_conflict_engine_exception", itemErr.Type)
+		assert.Equal(t, "dictionary", itemErr.Index)
+		assert.True(t, IsConflict(failed[0]))
+
+		stats := indexer.Stats()
+		assert.Equal(t, BulkIndexerStats{
+			NumAdded:    4,
+			NumFlushed:  3,
+			NumFailed:   1,
+			NumIndexed:  1,
+			NumUpdated:  1,
+			NumDeleted:  1,
+			NumRequests: 1,
+		}, stats)
+	})
+
+	t.Run("RetriesRejectedItems", func(t *testing.T) {
+		indexer := newIndexer(t, []string{"bulkPartialTooManyRequests", "bulkSingle"}, []int{200, 200}, BulkIndexerConfig{FlushCount: 2, MaxRetries: 2})
+
+		ctx := context.Background()
+		assert.Nil(t, indexer.Add(ctx, BulkItem{DocumentID: "1", Document: map[string]string{"greek": "ἀγαθός"}}))
+		assert.Nil(t, indexer.Add(ctx, BulkItem{DocumentID: "2", Document: map[string]string{"greek": "ἄγγελος"}}))
+		assert.Nil(t, indexer.Close(ctx))
+
+		stats := indexer.Stats()
+		assert.Equal(t, uint64(2), stats.NumFlushed)
+		assert.Equal(t, uint64(0), stats.NumFailed)
+		assert.Equal(t, uint64(2), stats.NumRequests)
+		assert.Equal(t, uint64(1), stats.NumRetries)
+	})
+
+	t.Run("RetriesRejectedRequest", func(t *testing.T) {
+		indexer := newIndexer(t, []string{"tooManyRequests429", "bulkSingle"}, []int{429, 200}, BulkIndexerConfig{MaxRetries: 1})
+
+		ctx := context.Background()
+		assert.Nil(t, indexer.Add(ctx, BulkItem{DocumentID: "2", Document: map[string]string{"greek": "ἄγγελος"}}))
+		assert.Nil(t, indexer.Close(ctx))
+
+		stats := indexer.Stats()
+		assert.Equal(t, uint64(1), stats.NumFlushed)
+		assert.Equal(t, uint64(2), stats.NumRequests)
+	})
+
+	t.Run("RetriesByDefault", func(t *testing.T) {
+		indexer := newIndexer(t, []string{"tooManyRequests429", "bulkSingle"}, []int{429, 200}, BulkIndexerConfig{})
+
+		ctx := context.Background()
+		assert.Nil(t, indexer.Add(ctx, BulkItem{DocumentID: "2", Document: map[string]string{"greek": "ἄγγελος"}}))
+		assert.Nil(t, indexer.Close(ctx))
+
+		stats := indexer.Stats()
+		assert.Equal(t, uint64(1), stats.NumFlushed)
+		assert.Equal(t, uint64(2), stats.NumRequests)
+	})
+
+	t.Run("NegativeMaxRetriesDisablesRetries", func(t *testing.T) {
+		indexer := newIndexer(t, []string{"tooManyRequests429", "bulkSingle"}, []int{429, 200}, BulkIndexerConfig{MaxRetries: -1})
+
+		ctx := context.Background()
+		assert.Nil(t, indexer.Add(ctx, BulkItem{DocumentID: "2", Document: map[string]string{"greek": "ἄγγελος"}}))
+		assert.Nil(t, indexer.Close(ctx))
+
+		stats := indexer.Stats()
+		assert.Equal(t, uint64(1), stats.NumFailed)
+		assert.Equal(t, uint64(1), stats.NumRequests)
+	})
+
+	t.Run("CloseCancelsWorkers", func(t *testing.T) {
+		esClient, err := CreateMockClientWithStatusCodes([]string{"tooManyRequests429"}, []int{429})
+		assert.Nil(t, err)
+		document, err := NewDocumentImpl(esClient)
+		assert.Nil(t, err)
+
+		var failed []error
+		indexer, err := document.NewBulkIndexer(BulkIndexerConfig{
+			Index:        index,
+			NumWorkers:   1,
+			MaxRetries:   10,
+			RetryBackoff: func(attempt int) time.Duration { return time.Hour },
+		})
+		assert.Nil(t, err)
+
+		assert.Nil(t, indexer.Add(context.Background(), BulkItem{
+			DocumentID: "1",
+			Document:   map[string]string{"greek": "ἀγαθός"},
+			OnFailure: func(ctx context.Context, item BulkItem, res BulkResponseItem, err error) {
+				failed = append(failed, err)
+			},
+		}))
+
+		ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+		defer cancel()
+		err = indexer.Close(ctx)
+		assert.True(t, errors.Is(err, context.DeadlineExceeded))
+
+		// the worker has stopped, so its failure callback already ran
+		assert.Len(t, failed, 1)
+		assert.True(t, errors.Is(failed[0], context.Canceled))
+	})
+
+	t.Run("DefaultBackoffIsCapped", func(t *testing.T) {
+		assert.Equal(t, 100*time.Millisecond, defaultBulkBackoff(0))
+		assert.Equal(t, 800*time.Millisecond, defaultBulkBackoff(3))
+		assert.Equal(t, maxBulkBackoff, defaultBulkBackoff(7))
+		assert.Equal(t, maxBulkBackoff, defaultBulkBackoff(100))
+	})
+
+	t.Run("RequestFailureFailsAllItems", func(t *testing.T) {
+		var requestErr error
+		indexer := newIndexer(t, []string{"serviceDown"}, []int{502}, BulkIndexerConfig{
+			OnError: func(ctx context.Context, err error) { requestErr = err },
+		})
+
+		ctx := context.Background()
+		assert.Nil(t, indexer.Add(ctx, BulkItem{DocumentID: "1", Document: map[string]string{"greek": "ἀγαθός"}}))
+		assert.Nil(t, indexer.Add(ctx, BulkItem{DocumentID: "2", Document: map[string]string{"greek": "ἄγγελος"}}))
+		assert.Nil(t, indexer.Close(ctx))
+
+		var elasticErr *ElasticError
+		assert.True(t, errors.As(requestErr, &elasticErr))
+		assert.Equal(t, uint64(2), indexer.Stats().NumFailed)
+	})
+
+	t.Run("AddAfterClose", func(t *testing.T) {
+		indexer := newIndexer(t, []string{"bulkSingle"}, []int{200}, BulkIndexerConfig{})
+		assert.Nil(t, indexer.Close(context.Background()))
+
+		err := indexer.Add(context.Background(), BulkItem{DocumentID: "1", Document: map[string]string{}})
+		assert.True(t, errors.Is(err, ErrBulkIndexerClosed))
+	})
+
+	t.Run("EncodeItems", func(t *testing.T) {
+		sut, err := encodeBulkItem(BulkItem{Action: BulkUpdate, DocumentID: "3", Document: map[string]string{"english": "reason"}}, index)
+		assert.Nil(t, err)
+		assert.Equal(t, "{\"update\":{\"_id\":\"3\"}}\n{\"doc\":{\"english\":\"reason\"}}\n", string(sut))
+
+		sut, err = encodeBulkItem(BulkItem{Index: "other", Body: []byte(`{"greek":"ἄγω"}`)}, index)
+		assert.Nil(t, err)
+		assert.Equal(t, "{\"index\":{\"_index\":\"other\"}}\n{\"greek\":\"ἄγω\"}\n", string(sut))
+
+		_, err = encodeBulkItem(BulkItem{Action: BulkDelete}, index)
+		assert.NotNil(t, err)
+		_, err = encodeBulkItem(BulkItem{Action: "upsert", DocumentID: "1"}, index)
+		assert.NotNil(t, err)
+		_, err = encodeBulkItem(BulkItem{DocumentID: "1"}, "")
+		assert.NotNil(t, err)
+	})
+}
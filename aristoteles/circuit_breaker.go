@@ -0,0 +1,135 @@
+package aristoteles
+
+import (
+	"errors"
+	"sync"
+	"time"
+)
+
+var ErrCircuitOpen = errors.New("elasticsearch circuit breaker is open")
+
+type CircuitState int
+
+const (
+	CircuitClosed CircuitState = iota
+	CircuitOpen
+	CircuitHalfOpen
+)
+
+func (s CircuitState) String() string {
+	switch s {
+	case CircuitClosed:
+		return "closed"
+	case CircuitOpen:
+		return "open"
+	case CircuitHalfOpen:
+		return "half-open"
+	default:
+		return "unknown"
+	}
+}
+
+type CircuitBreakerOptions struct {
+	// FailureThreshold is the number of consecutive failures that opens the circuit.
+	FailureThreshold int
+	// OpenTimeout is how long the circuit stays open before a single probe
+	// request is let through.
+	OpenTimeout time.Duration
+}
+
+// CircuitBreaker short-circuits requests once the cluster keeps failing, so
+// callers fail fast instead of waiting on timeouts and retries.
+type CircuitBreaker struct {
+	mu       sync.Mutex
+	options  CircuitBreakerOptions
+	state    CircuitState
+	failures int
+	openedAt time.Time
+	probing  bool
+	now      func() time.Time
+}
+
+func NewCircuitBreaker(options CircuitBreakerOptions) *CircuitBreaker {
+	if options.FailureThreshold <= 0 {
+		options.FailureThreshold = 5
+	}
+	if options.OpenTimeout <= 0 {
+		options.OpenTimeout = 30 * time.Second
+	}
+
+	return &CircuitBreaker{options: options, now: time.Now}
+}
+
+// Allow reports whether a request may be sent. Every allowed request must be
+// followed by Success or Failure.
+func (c *CircuitBreaker) Allow() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	switch c.state {
+	case CircuitOpen:
+		if c.now().Sub(c.openedAt) < c.options.OpenTimeout {
+			return ErrCircuitOpen
+		}
+		c.state = CircuitHalfOpen
+		c.probing = true
+		return nil
+	case CircuitHalfOpen:
+		if c.probing {
+			return ErrCircuitOpen
+		}
+		c.probing = true
+		return nil
+	default:
+		return nil
+	}
+}
+
+func (c *CircuitBreaker) Success() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.state = CircuitClosed
+	c.failures = 0
+	c.probing = false
+}
+
+func (c *CircuitBreaker) Failure() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.probing = false
+	if c.state == CircuitHalfOpen {
+		c.trip()
+		return
+	}
+
+	c.failures++
+	if c.failures >= c.options.FailureThreshold {
+		c.trip()
+	}
+}
+
+// release gives up a half-open probe slot without judging the cluster.
+func (c *CircuitBreaker) release() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.probing = false
+}
+
+func (c *CircuitBreaker) State() CircuitState {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.state == CircuitOpen && c.now().Sub(c.openedAt) >= c.options.OpenTimeout {
+		return CircuitHalfOpen
+	}
+	return c.state
+}
+
+func (c *CircuitBreaker) trip() {
+	c.state = CircuitOpen
+	c.openedAt = c.now()
+	c.failures = 0
+}
@@ -0,0 +1,173 @@
+package aristoteles
+
+import (
+	"context"
+	"errors"
+	"io"
+	"math/rand/v2"
+	"net/http"
+	"time"
+
+	"github.com/elastic/go-elasticsearch/v9"
+)
+
+// ClientOptions tunes how the client treats a slow or failing cluster. The
+// zero value keeps the go-elasticsearch defaults, which is what NewClient uses.
+type ClientOptions struct {
+	// RetryOnStatus replaces the default retry statuses (502, 503, 504).
+	RetryOnStatus []int
+	// MaxRetries keeps the go-elasticsearch default (3) when zero, a negative
+	// value disables retries.
+	MaxRetries int
+	// InitialBackoff is doubled for every retry up to MaxBackoff, with jitter.
+	// Without it retries are sent immediately.
+	InitialBackoff time.Duration
+	MaxBackoff     time.Duration
+	// RequestTimeout bounds every single attempt, retries included.
+	RequestTimeout time.Duration
+	RetryOnTimeout bool
+	// CircuitBreaker is disabled when nil.
+	CircuitBreaker *CircuitBreakerOptions
+}
+
+func DefaultClientOptions() ClientOptions {
+	return ClientOptions{
+		RetryOnStatus:  []int{http.StatusTooManyRequests, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout},
+		MaxRetries:     3,
+		InitialBackoff: 100 * time.Millisecond,
+		MaxBackoff:     5 * time.Second,
+		RequestTimeout: 30 * time.Second,
+		RetryOnTimeout: true,
+		CircuitBreaker: &CircuitBreakerOptions{FailureThreshold: 5, OpenTimeout: 30 * time.Second},
+	}
+}
+
+// apply configures cfg and wraps its transport, returning the circuit breaker
+// when one is enabled.
+func (o ClientOptions) apply(cfg *elasticsearch.Config) *CircuitBreaker {
+	if len(o.RetryOnStatus) > 0 {
+		cfg.RetryOnStatus = o.RetryOnStatus
+	}
+	switch {
+	case o.MaxRetries > 0:
+		cfg.MaxRetries = o.MaxRetries
+	case o.MaxRetries < 0:
+		cfg.DisableRetry = true
+	}
+	if o.InitialBackoff > 0 {
+		cfg.RetryBackoff = exponentialBackoff(o.InitialBackoff, o.MaxBackoff)
+	}
+
+	if o.RequestTimeout <= 0 && o.CircuitBreaker == nil {
+		return nil
+	}
+
+	cfg.RetryOnError = func(req *http.Request, err error) bool {
+		if errors.Is(err, ErrCircuitOpen) || req.Context().Err() != nil {
+			return false
+		}
+		if errors.Is(err, errAttemptTimeout) {
+			return o.RetryOnTimeout
+		}
+		return true
+	}
+
+	base := cfg.Transport
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	transport := &resilientTransport{base: base, timeout: o.RequestTimeout}
+	if o.CircuitBreaker != nil {
+		transport.breaker = NewCircuitBreaker(*o.CircuitBreaker)
+	}
+	cfg.Transport = transport
+
+	return transport.breaker
+}
+
+// exponentialBackoff returns a backoff with "equal jitter": half of the
+// exponential delay is fixed, the other half random.
+func exponentialBackoff(initial, max time.Duration) func(attempt int) time.Duration {
+	if max <= 0 {
+		max = 30 * initial
+	}
+	return func(attempt int) time.Duration {
+		delay := initial
+		for i := 1; i < attempt && delay < max; i++ {
+			delay *= 2
+		}
+		if delay > max {
+			delay = max
+		}
+		half := delay / 2
+		return half + rand.N(half+1)
+	}
+}
+
+var errAttemptTimeout = errors.New("elasticsearch request attempt timed out")
+
+type resilientTransport struct {
+	base    http.RoundTripper
+	timeout time.Duration
+	breaker *CircuitBreaker
+}
+
+func (t *resilientTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if t.breaker != nil {
+		if err := t.breaker.Allow(); err != nil {
+			return nil, err
+		}
+	}
+
+	parent := req.Context()
+	cancel := context.CancelFunc(func() {})
+	if t.timeout > 0 {
+		var ctx context.Context
+		ctx, cancel = context.WithTimeout(req.Context(), t.timeout)
+		req = req.WithContext(ctx)
+	}
+
+	res, err := t.base.RoundTrip(req)
+	if err != nil {
+		attemptTimedOut := parent.Err() == nil && req.Context().Err() != nil
+		cancel()
+		t.record(parent, nil, err)
+		if attemptTimedOut {
+			return nil, errors.Join(errAttemptTimeout, err)
+		}
+		return nil, err
+	}
+
+	t.record(parent, res, nil)
+	res.Body = &cancelOnClose{ReadCloser: res.Body, cancel: cancel}
+	return res, nil
+}
+
+func (t *resilientTransport) record(parent context.Context, res *http.Response, err error) {
+	if t.breaker == nil {
+		return
+	}
+
+	switch {
+	case err != nil && parent.Err() != nil:
+		// the caller gave up, that says nothing about the cluster
+		t.breaker.release()
+	case err != nil:
+		t.breaker.Failure()
+	case res.StatusCode == http.StatusBadGateway, res.StatusCode == http.StatusServiceUnavailable, res.StatusCode == http.StatusGatewayTimeout:
+		t.breaker.Failure()
+	default:
+		t.breaker.Success()
+	}
+}
+
+type cancelOnClose struct {
+	io.ReadCloser
+	cancel context.CancelFunc
+}
+
+func (c *cancelOnClose) Close() error {
+	err := c.ReadCloser.Close()
+	c.cancel()
+	return err
+}
@@ -0,0 +1,160 @@
+package aristoteles
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/odysseia-greek/agora/aristoteles/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func newInfoServer(t *testing.T, handler func(hit int64, w http.ResponseWriter) bool) (*httptest.Server, *atomic.Int64) {
+	t.Helper()
+	var hits atomic.Int64
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hit := hits.Add(1)
+		w.Header().Set("X-Elastic-Product", "Elasticsearch")
+		if handler(hit, w) {
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"name":"node-0","cluster_name":"odysseia","version":{"number":"9.0.0"}}`))
+	}))
+	t.Cleanup(server.Close)
+	return server, &hits
+}
+
+func TestClientOptions(t *testing.T) {
+	t.Run("RetriesOnStatusWithBackoff", func(t *testing.T) {
+		server, hits := newInfoServer(t, func(hit int64, w http.ResponseWriter) bool {
+			if hit <= 2 {
+				w.WriteHeader(http.StatusTooManyRequests)
+				return true
+			}
+			return false
+		})
+
+		options := DefaultClientOptions()
+		options.InitialBackoff = time.Millisecond
+		options.MaxBackoff = 5 * time.Millisecond
+		testClient, err := NewClientWithOptions(models.Config{Service: server.URL}, options)
+		assert.Nil(t, err)
+
+		health := testClient.Health().Info()
+		assert.True(t, health.Healthy)
+		assert.Equal(t, "closed", health.CircuitBreaker)
+		assert.Equal(t, int64(3), hits.Load())
+	})
+
+	t.Run("NegativeMaxRetriesDisablesRetries", func(t *testing.T) {
+		server, hits := newInfoServer(t, func(hit int64, w http.ResponseWriter) bool {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return true
+		})
+
+		options := DefaultClientOptions()
+		options.MaxRetries = -1
+		testClient, err := NewClientWithOptions(models.Config{Service: server.URL}, options)
+		assert.Nil(t, err)
+
+		health := testClient.Health().Info()
+		assert.False(t, health.Healthy)
+		assert.Equal(t, int64(1), hits.Load())
+	})
+
+	t.Run("PerRequestTimeout", func(t *testing.T) {
+		server, hits := newInfoServer(t, func(hit int64, w http.ResponseWriter) bool {
+			time.Sleep(200 * time.Millisecond)
+			return false
+		})
+
+		testClient, err := NewClientWithOptions(models.Config{Service: server.URL}, ClientOptions{
+			RequestTimeout: 20 * time.Millisecond,
+		})
+		assert.Nil(t, err)
+
+		start := time.Now()
+		health := testClient.Health().Info()
+		assert.False(t, health.Healthy)
+		assert.Empty(t, health.CircuitBreaker)
+		assert.Less(t, time.Since(start), 150*time.Millisecond)
+		assert.Equal(t, int64(1), hits.Load())
+	})
+
+	t.Run("CircuitBreakerOpensAndRecovers", func(t *testing.T) {
+		var down atomic.Bool
+		down.Store(true)
+		server, hits := newInfoServer(t, func(hit int64, w http.ResponseWriter) bool {
+			if down.Load() {
+				w.WriteHeader(http.StatusServiceUnavailable)
+				return true
+			}
+			return false
+		})
+
+		testClient, err := NewClientWithOptions(models.Config{Service: server.URL}, ClientOptions{
+			MaxRetries:     1,
+			CircuitBreaker: &CircuitBreakerOptions{FailureThreshold: 2, OpenTimeout: 50 * time.Millisecond},
+		})
+		assert.Nil(t, err)
+
+		health := testClient.Health().Info()
+		assert.False(t, health.Healthy)
+		assert.Equal(t, "open", health.CircuitBreaker)
+		assert.Equal(t, int64(2), hits.Load())
+
+		health = testClient.Health().Info()
+		assert.False(t, health.Healthy)
+		assert.Equal(t, int64(2), hits.Load())
+
+		down.Store(false)
+		time.Sleep(60 * time.Millisecond)
+		health = testClient.Health().Info()
+		assert.True(t, health.Healthy)
+		assert.Equal(t, "closed", health.CircuitBreaker)
+		assert.Equal(t, int64(3), hits.Load())
+	})
+}
+
+func TestCircuitBreaker(t *testing.T) {
+	now := time.Now()
+	breaker := NewCircuitBreaker(CircuitBreakerOptions{FailureThreshold: 2, OpenTimeout: time.Minute})
+	breaker.now = func() time.Time { return now }
+
+	assert.Nil(t, breaker.Allow())
+	breaker.Failure()
+	assert.Equal(t, CircuitClosed, breaker.State())
+	breaker.Failure()
+	assert.Equal(t, CircuitOpen, breaker.State())
+	assert.True(t, errors.Is(breaker.Allow(), ErrCircuitOpen))
+
+	now = now.Add(time.Minute)
+	assert.Equal(t, CircuitHalfOpen, breaker.State())
+	assert.Nil(t, breaker.Allow())
+	assert.True(t, errors.Is(breaker.Allow(), ErrCircuitOpen), "only one probe in half-open")
+
+	breaker.Failure()
+	assert.Equal(t, CircuitOpen, breaker.State())
+
+	now = now.Add(time.Minute)
+	assert.Nil(t, breaker.Allow())
+	breaker.release()
+	assert.Nil(t, breaker.Allow())
+	breaker.Success()
+	assert.Equal(t, CircuitClosed, breaker.State())
+}
+
+func TestExponentialBackoff(t *testing.T) {
+	backoff := exponentialBackoff(100*time.Millisecond, time.Second)
+	for attempt, expected := range map[int]time.Duration{1: 100 * time.Millisecond, 2: 200 * time.Millisecond, 4: 800 * time.Millisecond, 10: time.Second} {
+		for i := 0; i < 20; i++ {
+			delay := backoff(attempt)
+			assert.GreaterOrEqual(t, delay, expected/2)
+			assert.LessOrEqual(t, delay, expected)
+		}
+	}
+}
@@ -2,9 +2,14 @@ package aristoteles
 
 import (
 	"fmt"
-	"github.com/odysseia-greek/agora/aristoteles/models"
 	"os"
+	"regexp"
+	"strconv"
+	"strings"
 	"time"
+
+	"github.com/odysseia-greek/agora/aristoteles/models"
+	"gopkg.in/yaml.v3"
 )
 
 const (
@@ -16,6 +21,16 @@ const (
 	EnvElasticService        = "ELASTIC_SEARCH_SERVICE"
 	EnvElasticUser           = "ELASTIC_SEARCH_USER"
 	EnvElasticPassword       = "ELASTIC_SEARCH_PASSWORD"
+	EnvElasticNodes          = "ELASTIC_SEARCH_NODES"
+	EnvElasticAPIKey         = "ELASTIC_SEARCH_API_KEY"
+	EnvElasticServiceToken   = "ELASTIC_SEARCH_SERVICE_TOKEN"
+	EnvElasticFingerprint    = "ELASTIC_SEARCH_CA_FINGERPRINT"
+	EnvElasticClientCert     = "ELASTIC_SEARCH_CLIENT_CERT"
+	EnvElasticClientKey      = "ELASTIC_SEARCH_CLIENT_KEY"
+	EnvElasticSniff          = "ELASTIC_SEARCH_SNIFF"
+	EnvElasticSniffInterval  = "ELASTIC_SEARCH_SNIFF_INTERVAL"
+	EnvElasticCompress       = "ELASTIC_SEARCH_COMPRESS"
+	EnvElasticConfigFile     = "ELASTIC_SEARCH_CONFIG"
 )
 
 func HealthCheck(client Client) error {
@@ -42,7 +57,13 @@ func ElasticService(tls bool) string {
 	return elasticService
 }
 
+// ElasticConfig builds the config from the environment. When
+// ELASTIC_SEARCH_CONFIG points at a YAML file that file is loaded instead.
 func ElasticConfig(tls bool) (models.Config, error) {
+	if path := os.Getenv(EnvElasticConfigFile); path != "" {
+		return LoadConfigFile(path)
+	}
+
 	elasticUser := os.Getenv(EnvElasticUser)
 	if elasticUser == "" {
 		elasticUser = elasticUsernameDefault
@@ -52,8 +73,10 @@ func ElasticConfig(tls bool) (models.Config, error) {
 		elasticPassword = elasticPasswordDefault
 	}
 
+	fingerprint := os.Getenv(EnvElasticFingerprint)
+
 	var elasticCert string
-	if tls {
+	if tls && fingerprint == "" {
 		cert, err := GetCert()
 		if err != nil {
 			return models.Config{}, err
@@ -64,15 +87,144 @@ func ElasticConfig(tls bool) (models.Config, error) {
 	elasticService := ElasticService(tls)
 
 	esConf := models.Config{
-		Service:     elasticService,
-		Username:    elasticUser,
-		Password:    elasticPassword,
-		ElasticCERT: elasticCert,
+		Service:                elasticService,
+		Username:               elasticUser,
+		Password:               elasticPassword,
+		ElasticCERT:            elasticCert,
+		APIKey:                 os.Getenv(EnvElasticAPIKey),
+		ServiceToken:           os.Getenv(EnvElasticServiceToken),
+		CertificateFingerprint: fingerprint,
+	}
+
+	if nodes := os.Getenv(EnvElasticNodes); nodes != "" {
+		for _, node := range strings.Split(nodes, ",") {
+			if node = strings.TrimSpace(node); node != "" {
+				esConf.Nodes = append(esConf.Nodes, node)
+			}
+		}
+	}
+
+	var err error
+	if esConf.DiscoverNodesOnStart, err = envBool(EnvElasticSniff); err != nil {
+		return models.Config{}, err
+	}
+	if esConf.CompressRequestBody, err = envBool(EnvElasticCompress); err != nil {
+		return models.Config{}, err
+	}
+	if interval := os.Getenv(EnvElasticSniffInterval); interval != "" {
+		if esConf.DiscoverNodesInterval, err = time.ParseDuration(interval); err != nil {
+			return models.Config{}, fmt.Errorf("%s: %w", EnvElasticSniffInterval, err)
+		}
+	}
+
+	if err := loadClientCertificate(&esConf, os.Getenv(EnvElasticClientCert), os.Getenv(EnvElasticClientKey)); err != nil {
+		return models.Config{}, err
+	}
+
+	return esConf, nil
+}
+
+var envReference = regexp.MustCompile(`\$\{([A-Za-z_][A-Za-z0-9_]*)\}`)
+
+// expandEnv replaces ${VAR} with the value of VAR and fails on unset variables.
+func expandEnv(raw string) (string, error) {
+	var missing []string
+	expanded := envReference.ReplaceAllStringFunc(raw, func(reference string) string {
+		name := envReference.FindStringSubmatch(reference)[1]
+		value, ok := os.LookupEnv(name)
+		if !ok {
+			missing = append(missing, name)
+		}
+		return value
+	})
+	if len(missing) > 0 {
+		return "", fmt.Errorf("environment variables not set: %s", strings.Join(missing, ", "))
+	}
+
+	return expanded, nil
+}
+
+type fileConfig struct {
+	models.Config  `yaml:",inline"`
+	CACertFile     string `yaml:"caCertFile"`
+	ClientCertFile string `yaml:"clientCertFile"`
+	ClientKeyFile  string `yaml:"clientKeyFile"`
+}
+
+// LoadConfigFile reads a YAML config. ${VAR} references are expanded from the
+// environment so secrets do not have to live in the file, and the *File keys
+// are read into the matching PEM fields. Only the braced form is expanded, so
+// a literal $ in a value is kept, and an unset variable is an error.
+func LoadConfigFile(path string) (models.Config, error) {
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return models.Config{}, err
+	}
+
+	expanded, err := expandEnv(string(raw))
+	if err != nil {
+		return models.Config{}, fmt.Errorf("parsing %s: %w", path, err)
+	}
+
+	var file fileConfig
+	if err := yaml.Unmarshal([]byte(expanded), &file); err != nil {
+		return models.Config{}, fmt.Errorf("parsing %s: %w", path, err)
+	}
+
+	esConf := file.Config
+	if file.CACertFile != "" {
+		cert, err := os.ReadFile(file.CACertFile)
+		if err != nil {
+			return models.Config{}, err
+		}
+		esConf.ElasticCERT = string(cert)
+	}
+	if err := loadClientCertificate(&esConf, file.ClientCertFile, file.ClientKeyFile); err != nil {
+		return models.Config{}, err
+	}
+
+	if esConf.Service == "" && len(esConf.Nodes) == 0 {
+		return models.Config{}, fmt.Errorf("%s: no service or nodes configured", path)
 	}
 
 	return esConf, nil
 }
 
+func loadClientCertificate(esConf *models.Config, certPath, keyPath string) error {
+	if certPath == "" && keyPath == "" {
+		return nil
+	}
+	if certPath == "" || keyPath == "" {
+		return fmt.Errorf("client certificate and key must be configured together")
+	}
+
+	cert, err := os.ReadFile(certPath)
+	if err != nil {
+		return err
+	}
+	key, err := os.ReadFile(keyPath)
+	if err != nil {
+		return err
+	}
+
+	esConf.ClientCERT = string(cert)
+	esConf.ClientKEY = string(key)
+	return nil
+}
+
+func envBool(name string) (bool, error) {
+	value := os.Getenv(name)
+	if value == "" {
+		return false, nil
+	}
+
+	parsed, err := strconv.ParseBool(value)
+	if err != nil {
+		return false, fmt.Errorf("%s: %w", name, err)
+	}
+	return parsed, nil
+}
+
 func GetCert() ([]byte, error) {
 	cert, err := os.ReadFile(certPathInPod)
 	if err != nil {
@@ -0,0 +1,224 @@
+package aristoteles
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/odysseia-greek/agora/aristoteles/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestElasticConfigFromEnv(t *testing.T) {
+	t.Run("Defaults", func(t *testing.T) {
+		t.Setenv(EnvElasticService, "")
+		t.Setenv(EnvElasticUser, "")
+		t.Setenv(EnvElasticNodes, "")
+		config, err := ElasticConfig(false)
+		assert.Nil(t, err)
+		assert.Equal(t, elasticServiceDefault, config.Service)
+		assert.Equal(t, elasticUsernameDefault, config.Username)
+		assert.Equal(t, []string{elasticServiceDefault}, config.Addresses())
+	})
+
+	t.Run("MultiNodeAndAuth", func(t *testing.T) {
+		t.Setenv(EnvElasticNodes, "http://es-0:9200, http://es-1:9200,")
+		t.Setenv(EnvElasticAPIKey, "aWQ6a2V5")
+		t.Setenv(EnvElasticSniff, "true")
+		t.Setenv(EnvElasticSniffInterval, "5m")
+		t.Setenv(EnvElasticCompress, "1")
+		t.Setenv(EnvElasticFingerprint, "ab:cd")
+
+		config, err := ElasticConfig(true)
+		assert.Nil(t, err)
+		assert.Equal(t, []string{"http://es-0:9200", "http://es-1:9200"}, config.Addresses())
+		assert.Equal(t, "aWQ6a2V5", config.APIKey)
+		assert.True(t, config.DiscoverNodesOnStart)
+		assert.Equal(t, 5*time.Minute, config.DiscoverNodesInterval)
+		assert.True(t, config.CompressRequestBody)
+		assert.Empty(t, config.ElasticCERT, "a pinned fingerprint replaces the pod certificate")
+	})
+
+	t.Run("InvalidBool", func(t *testing.T) {
+		t.Setenv(EnvElasticSniff, "sometimes")
+
+		_, err := ElasticConfig(false)
+		assert.NotNil(t, err)
+	})
+
+	t.Run("ClientCertWithoutKey", func(t *testing.T) {
+		t.Setenv(EnvElasticClientCert, "/tmp/cert.pem")
+
+		_, err := ElasticConfig(false)
+		assert.NotNil(t, err)
+	})
+}
+
+func TestLoadConfigFile(t *testing.T) {
+	dir := t.TempDir()
+	caPath := filepath.Join(dir, "ca.pem")
+	assert.Nil(t, os.WriteFile(caPath, []byte("ca-pem"), 0o600))
+
+	t.Run("Yaml", func(t *testing.T) {
+		t.Setenv("TEST_ELASTIC_TOKEN", "secret-token")
+		path := filepath.Join(dir, "elastic.yaml")
+		assert.Nil(t, os.WriteFile(path, []byte(`
+nodes:
+  - https://es-0:9200
+  - https://es-1:9200
+serviceToken: ${TEST_ELASTIC_TOKEN}
+caCertFile: `+caPath+`
+discoverNodesOnStart: true
+discoverNodesInterval: 30s
+compressRequestBody: true
+`), 0o600))
+
+		config, err := LoadConfigFile(path)
+		assert.Nil(t, err)
+		assert.Equal(t, []string{"https://es-0:9200", "https://es-1:9200"}, config.Nodes)
+		assert.Equal(t, "secret-token", config.ServiceToken)
+		assert.Equal(t, "ca-pem", config.ElasticCERT)
+		assert.Equal(t, 30*time.Second, config.DiscoverNodesInterval)
+		assert.True(t, config.CompressRequestBody)
+	})
+
+	t.Run("DollarInPassword", func(t *testing.T) {
+		t.Setenv("TEST_ELASTIC_USER", "sokrates")
+		path := filepath.Join(dir, "password.yaml")
+		assert.Nil(t, os.WriteFile(path, []byte("service: http://es:9200\nusername: ${TEST_ELASTIC_USER}\npassword: s3cr$et_pw\n"), 0o600))
+
+		config, err := LoadConfigFile(path)
+		assert.Nil(t, err)
+		assert.Equal(t, "sokrates", config.Username)
+		assert.Equal(t, "s3cr$et_pw", config.Password)
+	})
+
+	t.Run("UnsetVariable", func(t *testing.T) {
+		path := filepath.Join(dir, "unset.yaml")
+		assert.Nil(t, os.WriteFile(path, []byte("service: http://es:9200\npassword: ${TEST_ELASTIC_UNSET_PASSWORD}\n"), 0o600))
+
+		_, err := LoadConfigFile(path)
+		assert.NotNil(t, err)
+		assert.Contains(t, err.Error(), "TEST_ELASTIC_UNSET_PASSWORD")
+	})
+
+	t.Run("ThroughEnv", func(t *testing.T) {
+		path := filepath.Join(dir, "service.yaml")
+		assert.Nil(t, os.WriteFile(path, []byte("service: http://es:9200\napiKey: aWQ6a2V5\n"), 0o600))
+		t.Setenv(EnvElasticConfigFile, path)
+
+		config, err := ElasticConfig(true)
+		assert.Nil(t, err)
+		assert.Equal(t, "http://es:9200", config.Service)
+		assert.Equal(t, "aWQ6a2V5", config.APIKey)
+	})
+
+	t.Run("NoAddresses", func(t *testing.T) {
+		path := filepath.Join(dir, "empty.yaml")
+		assert.Nil(t, os.WriteFile(path, []byte("username: elastic\n"), 0o600))
+
+		_, err := LoadConfigFile(path)
+		assert.NotNil(t, err)
+	})
+
+	t.Run("Missing", func(t *testing.T) {
+		_, err := LoadConfigFile(filepath.Join(dir, "missing.yaml"))
+		assert.NotNil(t, err)
+	})
+}
+
+func TestClientFromConfig(t *testing.T) {
+	t.Run("ApiKeyAndFingerprint", func(t *testing.T) {
+		var authorization string
+		server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			authorization = r.Header.Get("Authorization")
+			w.Header().Set("X-Elastic-Product", "Elasticsearch")
+			w.Write([]byte(`{"name":"node-0","cluster_name":"odysseia","version":{"number":"9.0.0"}}`))
+		}))
+		defer server.Close()
+
+		fingerprint := sha256.Sum256(server.Certificate().Raw)
+		testClient, err := NewClient(models.Config{
+			Nodes:                  []string{server.URL},
+			Username:               "elastic",
+			Password:               "odysseia",
+			APIKey:                 "aWQ6a2V5",
+			CertificateFingerprint: hex.EncodeToString(fingerprint[:]),
+		})
+		assert.Nil(t, err)
+
+		health := testClient.Health().Info()
+		assert.True(t, health.Healthy)
+		assert.Equal(t, "APIKey aWQ6a2V5", authorization)
+	})
+
+	t.Run("FingerprintWithDefaultOptions", func(t *testing.T) {
+		server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("X-Elastic-Product", "Elasticsearch")
+			w.Write([]byte(`{"name":"node-0","cluster_name":"odysseia","version":{"number":"9.0.0"}}`))
+		}))
+		defer server.Close()
+
+		fingerprint := sha256.Sum256(server.Certificate().Raw)
+		testClient, err := NewClientWithOptions(models.Config{
+			Service:                server.URL,
+			CertificateFingerprint: hex.EncodeToString(fingerprint[:]),
+		}, DefaultClientOptions())
+		assert.Nil(t, err)
+		assert.True(t, testClient.Health().Info().Healthy)
+
+		options := DefaultClientOptions()
+		options.MaxRetries = -1
+		testClient, err = NewClientWithOptions(models.Config{
+			Service:                server.URL,
+			CertificateFingerprint: strings.Repeat("00", sha256.Size),
+		}, options)
+		assert.Nil(t, err)
+		assert.False(t, testClient.Health().Info().Healthy)
+	})
+
+	t.Run("FingerprintNeedsHTTPTransport", func(t *testing.T) {
+		transport := roundTripFunc(http.DefaultTransport.RoundTrip)
+
+		_, err := NewClient(models.Config{Service: "https://localhost:9200", CertificateFingerprint: "ab:cd", Transport: transport})
+		assert.NotNil(t, err)
+	})
+
+	t.Run("CustomTransport", func(t *testing.T) {
+		var used bool
+		transport := roundTripFunc(func(r *http.Request) (*http.Response, error) {
+			used = true
+			return http.DefaultTransport.RoundTrip(r)
+		})
+		server, _ := newInfoServer(t, func(int64, http.ResponseWriter) bool { return false })
+
+		testClient, err := NewClient(models.Config{Service: server.URL, Transport: transport})
+		assert.Nil(t, err)
+		assert.True(t, testClient.Health().Info().Healthy)
+		assert.True(t, used)
+	})
+
+	t.Run("TLSNeedsHTTPTransport", func(t *testing.T) {
+		transport := roundTripFunc(http.DefaultTransport.RoundTrip)
+
+		_, err := NewClient(models.Config{Service: "https://localhost:9200", ElasticCERT: "pem", Transport: transport})
+		assert.NotNil(t, err)
+	})
+
+	t.Run("InvalidClientCertificate", func(t *testing.T) {
+		_, err := NewClient(models.Config{Service: "https://localhost:9200", ClientCERT: "cert", ClientKEY: "key"})
+		assert.NotNil(t, err)
+	})
+}
+
+type roundTripFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(r *http.Request) (*http.Response, error) {
+	return f(r)
+}
@@ -0,0 +1,224 @@
+package aristoteles
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/elastic/go-elasticsearch/v9/esapi"
+	"github.com/odysseia-greek/agora/aristoteles/models"
+)
+
+// UpdateOptions controls how concurrent writes to the same document are
+// handled. IfMatch and RetryOnConflict exclude each other: a conditional write
+// must fail when the document changed, a retried one is re-applied to the
+// latest version.
+type UpdateOptions struct {
+	// IfMatch makes the write fail with a conflict unless the document is still
+	// at this version, see GetWithVersion and CreateResult.DocumentVersion.
+	IfMatch *models.DocumentVersion
+	// RetryOnConflict lets Elasticsearch re-run a partial or scripted update up
+	// to this many times when another writer got in between.
+	RetryOnConflict int
+}
+
+func (o UpdateOptions) apply(req *esapi.UpdateRequest) error {
+	if o.IfMatch != nil && o.RetryOnConflict > 0 {
+		return errors.New("update: IfMatch cannot be combined with RetryOnConflict")
+	}
+	if o.IfMatch != nil {
+		seqNo, primaryTerm := int(o.IfMatch.SeqNo), int(o.IfMatch.PrimaryTerm)
+		req.IfSeqNo = &seqNo
+		req.IfPrimaryTerm = &primaryTerm
+	}
+	if o.RetryOnConflict > 0 {
+		retries := o.RetryOnConflict
+		req.RetryOnConflict = &retries
+	}
+
+	return nil
+}
+
+// ByQueryOptions configures UpdateByQuery and DeleteByQuery.
+type ByQueryOptions struct {
+	// ProceedOnConflicts counts version conflicts instead of aborting.
+	ProceedOnConflicts bool
+	// Refresh refreshes the affected indices once the task is done.
+	Refresh bool
+	// PollInterval is the interval between task status checks, 1s when zero.
+	PollInterval time.Duration
+}
+
+func (o ByQueryOptions) conflicts() string {
+	if o.ProceedOnConflicts {
+		return "proceed"
+	}
+	return ""
+}
+
+// GetWithVersion returns a document with the seq_no and primary_term needed
+// for a conditional write.
+func (d *DocumentImpl) GetWithVersion(ctx context.Context, index, id string) (*models.DirectResponse, error) {
+	jsonBody, err := perform(ctx, d.es, esapi.GetRequest{Index: index, DocumentID: id}, "get document with version")
+	if err != nil {
+		return nil, err
+	}
+
+	var doc models.DirectResponse
+	if err := json.Unmarshal(jsonBody, &doc); err != nil {
+		return nil, err
+	}
+
+	return &doc, nil
+}
+
+// ReplaceIfMatch overwrites a document only if it is still at version.
+func (d *DocumentImpl) ReplaceIfMatch(ctx context.Context, index, id string, body []byte, version models.DocumentVersion) (*models.CreateResult, error) {
+	seqNo, primaryTerm := int(version.SeqNo), int(version.PrimaryTerm)
+	request := esapi.IndexRequest{
+		Index:         index,
+		DocumentID:    id,
+		Body:          bytes.NewReader(body),
+		IfSeqNo:       &seqNo,
+		IfPrimaryTerm: &primaryTerm,
+	}
+
+	return d.createResult(ctx, request, "replace document")
+}
+
+// UpdateWithOptions merges body into the stored document like Update.
+func (d *DocumentImpl) UpdateWithOptions(ctx context.Context, index, id string, body []byte, opts UpdateOptions) (*models.CreateResult, error) {
+	request, err := toJSONReader(map[string]interface{}{"doc": json.RawMessage(body)})
+	if err != nil {
+		return nil, err
+	}
+
+	return d.update(ctx, index, id, request, opts, "update document")
+}
+
+// UpdateWithScript runs a painless script against the stored document, e.g.
+// "ctx._source.progress += params.points". Scripts read the document on the
+// shard, so they do not lose concurrent increments.
+func (d *DocumentImpl) UpdateWithScript(ctx context.Context, index, id string, script models.Script, opts UpdateOptions) (*models.CreateResult, error) {
+	request, err := toJSONReader(map[string]interface{}{"script": script})
+	if err != nil {
+		return nil, err
+	}
+
+	return d.update(ctx, index, id, request, opts, "update document with script")
+}
+
+// Upsert merges body into the stored document or creates it from body when it
+// does not exist yet.
+func (d *DocumentImpl) Upsert(ctx context.Context, index, id string, body []byte, opts UpdateOptions) (*models.CreateResult, error) {
+	request, err := toJSONReader(map[string]interface{}{"doc": json.RawMessage(body), "doc_as_upsert": true})
+	if err != nil {
+		return nil, err
+	}
+
+	return d.update(ctx, index, id, request, opts, "upsert document")
+}
+
+// UpdateByQuery runs script on every document matching request and waits for
+// the task to finish. A nil script only bumps the version of each document,
+// which is how new mappings are picked up.
+func (d *DocumentImpl) UpdateByQuery(ctx context.Context, index string, request map[string]interface{}, script *models.Script, opts ByQueryOptions) (*models.ByQueryResponse, error) {
+	body := make(map[string]interface{}, len(request)+1)
+	for key, value := range request {
+		body[key] = value
+	}
+	if script != nil {
+		body["script"] = script
+	}
+
+	reader, err := toJSONReader(body)
+	if err != nil {
+		return nil, err
+	}
+
+	waitForCompletion := false
+	return d.byQuery(ctx, esapi.UpdateByQueryRequest{
+		Index:             []string{index},
+		Body:              reader,
+		Conflicts:         opts.conflicts(),
+		Refresh:           &opts.Refresh,
+		WaitForCompletion: &waitForCompletion,
+	}, opts.PollInterval, "update by query")
+}
+
+// DeleteByQuery deletes every document matching request and waits for the
+// task to finish.
+func (d *DocumentImpl) DeleteByQuery(ctx context.Context, index string, request map[string]interface{}, opts ByQueryOptions) (*models.ByQueryResponse, error) {
+	reader, err := toJSONReader(request)
+	if err != nil {
+		return nil, err
+	}
+
+	waitForCompletion := false
+	return d.byQuery(ctx, esapi.DeleteByQueryRequest{
+		Index:             []string{index},
+		Body:              reader,
+		Conflicts:         opts.conflicts(),
+		Refresh:           &opts.Refresh,
+		WaitForCompletion: &waitForCompletion,
+	}, opts.PollInterval, "delete by query")
+}
+
+func (d *DocumentImpl) update(ctx context.Context, index, id string, body io.Reader, opts UpdateOptions, operation string) (*models.CreateResult, error) {
+	request := esapi.UpdateRequest{
+		Index:      index,
+		DocumentID: id,
+		Body:       body,
+	}
+	if err := opts.apply(&request); err != nil {
+		return nil, err
+	}
+
+	return d.createResult(ctx, request, operation)
+}
+
+func (d *DocumentImpl) createResult(ctx context.Context, request esapi.Request, operation string) (*models.CreateResult, error) {
+	jsonBody, err := perform(ctx, d.es, request, operation)
+	if err != nil {
+		return nil, err
+	}
+
+	elasticResult, err := models.UnmarshalCreateResult(jsonBody)
+	if err != nil {
+		return nil, err
+	}
+
+	return &elasticResult, nil
+}
+
+func (d *DocumentImpl) byQuery(ctx context.Context, request esapi.Request, pollInterval time.Duration, operation string) (*models.ByQueryResponse, error) {
+	jsonBody, err := perform(ctx, d.es, request, operation)
+	if err != nil {
+		return nil, err
+	}
+
+	task, err := models.UnmarshalTaskSubmitResponse(jsonBody)
+	if err != nil {
+		return nil, err
+	}
+	if task.Task == "" {
+		return nil, fmt.Errorf("%s: no task id in response: %s", operation, jsonBody)
+	}
+
+	status, err := waitForTask(ctx, d.es, task.Task, pollInterval)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", operation, err)
+	}
+	if status.Response == nil {
+		return nil, fmt.Errorf("%s: task %s completed without a response", operation, task.Task)
+	}
+	if len(status.Response.Failures) > 0 {
+		return status.Response, fmt.Errorf("%s: %d failures, first: %s", operation, len(status.Response.Failures), status.Response.Failures[0])
+	}
+
+	return status.Response, nil
+}
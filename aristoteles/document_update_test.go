@@ -0,0 +1,181 @@
+package aristoteles
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/odysseia-greek/agora/aristoteles/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDocumentConcurrency(t *testing.T) {
+	index := "progress"
+	id := "sokrates"
+	ctx := context.Background()
+
+	newClient := func(t *testing.T) Client {
+		testClient, err := NewFakeClient(nil)
+		assert.Nil(t, err)
+		_, err = testClient.Document().CreateWithId(index, id, []byte(`{"points":1,"answered":["q1"]}`))
+		assert.Nil(t, err)
+		return testClient
+	}
+
+	t.Run("GetWithVersion", func(t *testing.T) {
+		testClient := newClient(t)
+
+		doc, err := testClient.Document().GetWithVersion(ctx, index, id)
+		assert.Nil(t, err)
+		assert.True(t, doc.Found)
+		assert.Equal(t, models.DocumentVersion{SeqNo: 0, PrimaryTerm: 1}, doc.DocumentVersion())
+		assert.JSONEq(t, `{"points":1,"answered":["q1"]}`, mustJSON(t, doc.Source))
+
+		_, err = testClient.Document().GetWithVersion(ctx, index, "plato")
+		assert.True(t, IsNotFound(err))
+	})
+
+	t.Run("ReplaceIfMatch", func(t *testing.T) {
+		testClient := newClient(t)
+		doc, err := testClient.Document().GetWithVersion(ctx, index, id)
+		assert.Nil(t, err)
+
+		res, err := testClient.Document().ReplaceIfMatch(ctx, index, id, []byte(`{"points":2}`), doc.DocumentVersion())
+		assert.Nil(t, err)
+		assert.Equal(t, "updated", res.Result)
+
+		_, err = testClient.Document().ReplaceIfMatch(ctx, index, id, []byte(`{"points":3}`), doc.DocumentVersion())
+		assert.True(t, IsConflict(err))
+	})
+
+	t.Run("UpdateIfMatch", func(t *testing.T) {
+		testClient := newClient(t)
+		doc, err := testClient.Document().GetWithVersion(ctx, index, id)
+		assert.Nil(t, err)
+		version := doc.DocumentVersion()
+
+		res, err := testClient.Document().UpdateWithOptions(ctx, index, id, []byte(`{"points":5}`), UpdateOptions{IfMatch: &version})
+		assert.Nil(t, err)
+		assert.NotEqual(t, version, res.DocumentVersion())
+
+		_, err = testClient.Document().UpdateWithOptions(ctx, index, id, []byte(`{"points":6}`), UpdateOptions{IfMatch: &version})
+		assert.True(t, IsConflict(err))
+
+		next := res.DocumentVersion()
+		_, err = testClient.Document().UpdateWithOptions(ctx, index, id, []byte(`{"points":6}`), UpdateOptions{IfMatch: &next})
+		assert.Nil(t, err)
+	})
+
+	t.Run("IfMatchWithRetryOnConflict", func(t *testing.T) {
+		testClient := newClient(t)
+
+		_, err := testClient.Document().UpdateWithOptions(ctx, index, id, []byte(`{"points":5}`), UpdateOptions{
+			IfMatch:         &models.DocumentVersion{},
+			RetryOnConflict: 3,
+		})
+		assert.NotNil(t, err)
+	})
+
+	t.Run("UpdateWithScript", func(t *testing.T) {
+		testClient := newClient(t)
+
+		_, err := testClient.Document().UpdateWithScript(ctx, index, id, models.Script{
+			Source: "ctx._source.points += params.points; ctx._source.answered.add(params.question)",
+			Lang:   "painless",
+			Params: map[string]interface{}{"points": 2, "question": "q2"},
+		}, UpdateOptions{RetryOnConflict: 3})
+		assert.Nil(t, err)
+
+		doc, err := testClient.Document().GetWithVersion(ctx, index, id)
+		assert.Nil(t, err)
+		assert.JSONEq(t, `{"points":3,"answered":["q1","q2"]}`, mustJSON(t, doc.Source))
+	})
+
+	t.Run("Upsert", func(t *testing.T) {
+		testClient := newClient(t)
+
+		res, err := testClient.Document().Upsert(ctx, index, "plato", []byte(`{"points":1}`), UpdateOptions{})
+		assert.Nil(t, err)
+		assert.Equal(t, "created", res.Result)
+
+		res, err = testClient.Document().Upsert(ctx, index, "plato", []byte(`{"answered":["q1"]}`), UpdateOptions{})
+		assert.Nil(t, err)
+		assert.Equal(t, "updated", res.Result)
+
+		doc, err := testClient.Document().GetWithVersion(ctx, index, "plato")
+		assert.Nil(t, err)
+		assert.JSONEq(t, `{"points":1,"answered":["q1"]}`, mustJSON(t, doc.Source))
+	})
+
+	t.Run("RetryOnConflictParam", func(t *testing.T) {
+		mock := NewExpectTransport()
+		mock.Expect(http.MethodPost, "/progress/_update/sokrates").RespondWithFixture(200, "updated")
+		testClient, err := NewExpectClient(mock)
+		assert.Nil(t, err)
+
+		_, err = testClient.Document().UpdateWithOptions(ctx, index, id, []byte(`{"points":5}`), UpdateOptions{RetryOnConflict: 3})
+		assert.Nil(t, err)
+		mock.AssertExpectations(t)
+		assert.Equal(t, "3", mock.Requests()[0].Query.Get("retry_on_conflict"))
+	})
+}
+
+func TestDocumentByQuery(t *testing.T) {
+	index := "dictionary"
+	ctx := context.Background()
+	query := NewBuilderImpl().MatchQuery("greek", "λόγος")
+	opts := ByQueryOptions{PollInterval: time.Millisecond, ProceedOnConflicts: true}
+
+	t.Run("UpdateByQuery", func(t *testing.T) {
+		mock := NewExpectTransport()
+		mock.Expect(http.MethodPost, "/dictionary/_update_by_query").RespondWithFixture(200, "reindexTask")
+		mock.Expect(http.MethodGet, "/_tasks/*").RespondWithFixture(200, "taskRunning")
+		mock.Expect(http.MethodGet, "/_tasks/*").RespondWithFixture(200, "updateByQueryCompleted")
+		testClient, err := NewExpectClient(mock)
+		assert.Nil(t, err)
+
+		res, err := testClient.Document().UpdateByQuery(ctx, index, query, &models.Script{
+			Source: "ctx._source.checked = params.checked",
+			Params: map[string]interface{}{"checked": true},
+		}, opts)
+		assert.Nil(t, err)
+		assert.Equal(t, int64(40), res.Updated)
+		assert.Equal(t, int64(2), res.VersionConflicts)
+		mock.AssertExpectations(t)
+
+		submit := mock.Requests()[0]
+		assert.Equal(t, "false", submit.Query.Get("wait_for_completion"))
+		assert.Equal(t, "proceed", submit.Query.Get("conflicts"))
+		var body map[string]interface{}
+		assert.Nil(t, json.Unmarshal(submit.Body, &body))
+		assert.Contains(t, body, "query")
+		assert.Contains(t, body, "script")
+		assert.NotContains(t, query, "script")
+	})
+
+	t.Run("DeleteByQueryFailures", func(t *testing.T) {
+		mock := NewExpectTransport()
+		mock.Expect(http.MethodPost, "/dictionary/_delete_by_query").RespondWithFixture(200, "reindexTask")
+		mock.Expect(http.MethodGet, "/_tasks/*").RespondWithFixture(200, "taskFailures")
+		testClient, err := NewExpectClient(mock)
+		assert.Nil(t, err)
+
+		res, err := testClient.Document().DeleteByQuery(ctx, index, query, ByQueryOptions{PollInterval: time.Millisecond})
+		assert.NotNil(t, err)
+		assert.Contains(t, err.Error(), "delete by query: 1 failures")
+		assert.Len(t, res.Failures, 1)
+		mock.AssertExpectations(t)
+	})
+
+	t.Run("SubmitError", func(t *testing.T) {
+		mock := NewExpectTransport()
+		mock.Expect(http.MethodPost, "/dictionary/_delete_by_query").RespondWithFixture(404, "deleteIndex404")
+		testClient, err := NewExpectClient(mock)
+		assert.Nil(t, err)
+
+		_, err = testClient.Document().DeleteByQuery(ctx, index, query, opts)
+		assert.True(t, IsNotFound(err))
+	})
+}
@@ -118,6 +118,8 @@ func (d *DocumentImpl) CreateWithIdAndFirstItemWithContext(ctx context.Context,
 	return &elasticResult, nil
 }
 
+// Update merges body into the stored document without any check for concurrent
+// writers; use UpdateWithOptions or UpdateWithScript when that matters.
 func (d *DocumentImpl) Update(index, id string, body []byte) (*models.CreateResult, error) {
 	return d.UpdateWithContext(context.Background(), index, id, body)
 }
@@ -149,6 +151,8 @@ func (d *DocumentImpl) UpdateWithContext(ctx context.Context, index, id string,
 	return &elasticResult, nil
 }
 
+// AddItemToDocument appends body to the list field paramName. Concurrent calls
+// can conflict; UpdateWithScript with RetryOnConflict retries them instead.
 func (d *DocumentImpl) AddItemToDocument(index, id, body, paramName string) (*models.CreateResult, error) {
 	return d.AddItemToDocumentWithContext(context.Background(), index, id, body, paramName)
 }
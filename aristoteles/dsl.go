@@ -0,0 +1,505 @@
+package aristoteles
+
+// QueryClause is a single node of the query DSL. Map returns the clause in the shape
+// Elasticsearch expects inside "query", e.g. {"term": {"author": "herodotos"}}.
+type QueryClause interface {
+	Map() map[string]interface{}
+}
+
+// SearchRequest builds a full search body. Build returns the same
+// map[string]interface{} shape that the Query.Match* methods accept.
+type SearchRequest struct {
+	query          QueryClause
+	from           *int
+	size           *int
+	sort           []interface{}
+	sourceDisabled bool
+	includes       []string
+	excludes       []string
+	highlight      *Highlight
+	aggregations   map[string]interface{}
+	searchAfter    []interface{}
+}
+
+func NewSearch() *SearchRequest {
+	return &SearchRequest{}
+}
+
+func (s *SearchRequest) Query(query QueryClause) *SearchRequest {
+	s.query = query
+	return s
+}
+
+func (s *SearchRequest) From(from int) *SearchRequest {
+	s.from = &from
+	return s
+}
+
+func (s *SearchRequest) Size(size int) *SearchRequest {
+	s.size = &size
+	return s
+}
+
+// Sort adds a sort on field in the given order ("asc" or "desc").
+func (s *SearchRequest) Sort(field, order string) *SearchRequest {
+	s.sort = append(s.sort, map[string]interface{}{
+		field: map[string]interface{}{"order": order},
+	})
+	return s
+}
+
+// SortWithMode adds a sort on a multi-valued field, e.g. mode "max".
+func (s *SearchRequest) SortWithMode(field, order, mode string) *SearchRequest {
+	s.sort = append(s.sort, map[string]interface{}{
+		field: map[string]interface{}{"order": order, "mode": mode},
+	})
+	return s
+}
+
+func (s *SearchRequest) SortByScore() *SearchRequest {
+	s.sort = append(s.sort, "_score")
+	return s
+}
+
+func (s *SearchRequest) SearchAfter(values ...interface{}) *SearchRequest {
+	s.searchAfter = values
+	return s
+}
+
+// Source limits the returned _source to the given fields.
+func (s *SearchRequest) Source(includes ...string) *SearchRequest {
+	s.includes = append(s.includes, includes...)
+	return s
+}
+
+func (s *SearchRequest) SourceExcludes(excludes ...string) *SearchRequest {
+	s.excludes = append(s.excludes, excludes...)
+	return s
+}
+
+func (s *SearchRequest) NoSource() *SearchRequest {
+	s.sourceDisabled = true
+	return s
+}
+
+func (s *SearchRequest) Highlight(highlight *Highlight) *SearchRequest {
+	s.highlight = highlight
+	return s
+}
+
+// TermsAggregation adds a terms aggregation named name, the same shape
+// Builder.Aggregate produces.
+func (s *SearchRequest) TermsAggregation(name, field string, size int) *SearchRequest {
+	if s.aggregations == nil {
+		s.aggregations = make(map[string]interface{})
+	}
+	s.aggregations[name] = map[string]interface{}{
+		"terms": map[string]interface{}{
+			"field": field,
+			"size":  size,
+		},
+	}
+	return s
+}
+
+func (s *SearchRequest) Build() map[string]interface{} {
+	body := map[string]interface{}{}
+	if s.query != nil {
+		body["query"] = s.query.Map()
+	}
+	if s.from != nil {
+		body["from"] = *s.from
+	}
+	if s.size != nil {
+		body["size"] = *s.size
+	}
+	if len(s.sort) > 0 {
+		body["sort"] = s.sort
+	}
+	if len(s.searchAfter) > 0 {
+		body["search_after"] = s.searchAfter
+	}
+
+	switch {
+	case s.sourceDisabled:
+		body["_source"] = false
+	case len(s.excludes) > 0:
+		source := map[string]interface{}{"excludes": s.excludes}
+		if len(s.includes) > 0 {
+			source["includes"] = s.includes
+		}
+		body["_source"] = source
+	case len(s.includes) > 0:
+		body["_source"] = s.includes
+	}
+
+	if s.highlight != nil {
+		body["highlight"] = s.highlight.Map()
+	}
+	if len(s.aggregations) > 0 {
+		body["aggs"] = s.aggregations
+	}
+
+	return body
+}
+
+type Highlight struct {
+	fields   map[string]interface{}
+	preTags  []string
+	postTags []string
+}
+
+func NewHighlight(fields ...string) *Highlight {
+	h := &Highlight{fields: make(map[string]interface{})}
+	for _, field := range fields {
+		h.fields[field] = map[string]interface{}{}
+	}
+	return h
+}
+
+func (h *Highlight) Field(field string) *Highlight {
+	h.fields[field] = map[string]interface{}{}
+	return h
+}
+
+func (h *Highlight) Tags(pre, post string) *Highlight {
+	h.preTags = []string{pre}
+	h.postTags = []string{post}
+	return h
+}
+
+func (h *Highlight) Map() map[string]interface{} {
+	highlight := map[string]interface{}{"fields": h.fields}
+	if len(h.preTags) > 0 {
+		highlight["pre_tags"] = h.preTags
+		highlight["post_tags"] = h.postTags
+	}
+	return highlight
+}
+
+type MatchAllQuery struct{}
+
+func NewMatchAllQuery() *MatchAllQuery {
+	return &MatchAllQuery{}
+}
+
+func (q *MatchAllQuery) Map() map[string]interface{} {
+	return map[string]interface{}{
+		"match_all": map[string]interface{}{},
+	}
+}
+
+type MatchQuery struct {
+	field     string
+	value     interface{}
+	operator  string
+	fuzziness string
+	analyzer  string
+}
+
+func NewMatchQuery(field string, value interface{}) *MatchQuery {
+	return &MatchQuery{field: field, value: value}
+}
+
+// Operator is "and" or "or" (the Elasticsearch default).
+func (q *MatchQuery) Operator(operator string) *MatchQuery {
+	q.operator = operator
+	return q
+}
+
+func (q *MatchQuery) Fuzziness(fuzziness string) *MatchQuery {
+	q.fuzziness = fuzziness
+	return q
+}
+
+func (q *MatchQuery) Analyzer(analyzer string) *MatchQuery {
+	q.analyzer = analyzer
+	return q
+}
+
+func (q *MatchQuery) Map() map[string]interface{} {
+	if q.operator == "" && q.fuzziness == "" && q.analyzer == "" {
+		return map[string]interface{}{
+			"match": map[string]interface{}{q.field: q.value},
+		}
+	}
+
+	options := map[string]interface{}{"query": q.value}
+	if q.operator != "" {
+		options["operator"] = q.operator
+	}
+	if q.fuzziness != "" {
+		options["fuzziness"] = q.fuzziness
+	}
+	if q.analyzer != "" {
+		options["analyzer"] = q.analyzer
+	}
+	return map[string]interface{}{
+		"match": map[string]interface{}{q.field: options},
+	}
+}
+
+type MatchPhraseQuery struct {
+	field  string
+	value  string
+	prefix bool
+}
+
+func NewMatchPhraseQuery(field, value string) *MatchPhraseQuery {
+	return &MatchPhraseQuery{field: field, value: value}
+}
+
+func NewMatchPhrasePrefixQuery(field, value string) *MatchPhraseQuery {
+	return &MatchPhraseQuery{field: field, value: value, prefix: true}
+}
+
+func (q *MatchPhraseQuery) Map() map[string]interface{} {
+	kind := "match_phrase"
+	if q.prefix {
+		kind = "match_phrase_prefix"
+	}
+	return map[string]interface{}{
+		kind: map[string]interface{}{q.field: q.value},
+	}
+}
+
+type MultiMatchQuery struct {
+	query     string
+	fields    []string
+	matchType string
+}
+
+func NewMultiMatchQuery(query string, fields ...string) *MultiMatchQuery {
+	return &MultiMatchQuery{query: query, fields: fields}
+}
+
+// Type sets the multi_match type, e.g. "best_fields" or "bool_prefix".
+func (q *MultiMatchQuery) Type(matchType string) *MultiMatchQuery {
+	q.matchType = matchType
+	return q
+}
+
+func (q *MultiMatchQuery) Map() map[string]interface{} {
+	options := map[string]interface{}{
+		"query":  q.query,
+		"fields": q.fields,
+	}
+	if q.matchType != "" {
+		options["type"] = q.matchType
+	}
+	return map[string]interface{}{"multi_match": options}
+}
+
+type TermQuery struct {
+	field string
+	value interface{}
+}
+
+func NewTermQuery(field string, value interface{}) *TermQuery {
+	return &TermQuery{field: field, value: value}
+}
+
+func (q *TermQuery) Map() map[string]interface{} {
+	return map[string]interface{}{
+		"term": map[string]interface{}{q.field: q.value},
+	}
+}
+
+type TermsQuery struct {
+	field  string
+	values []interface{}
+}
+
+func NewTermsQuery(field string, values ...interface{}) *TermsQuery {
+	return &TermsQuery{field: field, values: values}
+}
+
+func (q *TermsQuery) Map() map[string]interface{} {
+	return map[string]interface{}{
+		"terms": map[string]interface{}{q.field: q.values},
+	}
+}
+
+type RangeQuery struct {
+	field  string
+	bounds map[string]interface{}
+}
+
+func NewRangeQuery(field string) *RangeQuery {
+	return &RangeQuery{field: field, bounds: make(map[string]interface{})}
+}
+
+func (q *RangeQuery) Gt(value interface{}) *RangeQuery {
+	q.bounds["gt"] = value
+	return q
+}
+
+func (q *RangeQuery) Gte(value interface{}) *RangeQuery {
+	q.bounds["gte"] = value
+	return q
+}
+
+func (q *RangeQuery) Lt(value interface{}) *RangeQuery {
+	q.bounds["lt"] = value
+	return q
+}
+
+func (q *RangeQuery) Lte(value interface{}) *RangeQuery {
+	q.bounds["lte"] = value
+	return q
+}
+
+// Format sets the date format used to parse the bounds of a date range.
+func (q *RangeQuery) Format(format string) *RangeQuery {
+	q.bounds["format"] = format
+	return q
+}
+
+func (q *RangeQuery) Map() map[string]interface{} {
+	return map[string]interface{}{
+		"range": map[string]interface{}{q.field: q.bounds},
+	}
+}
+
+type PrefixQuery struct {
+	field string
+	value string
+}
+
+func NewPrefixQuery(field, value string) *PrefixQuery {
+	return &PrefixQuery{field: field, value: value}
+}
+
+func (q *PrefixQuery) Map() map[string]interface{} {
+	return map[string]interface{}{
+		"prefix": map[string]interface{}{q.field: q.value},
+	}
+}
+
+type WildcardQuery struct {
+	field string
+	value string
+}
+
+func NewWildcardQuery(field, value string) *WildcardQuery {
+	return &WildcardQuery{field: field, value: value}
+}
+
+func (q *WildcardQuery) Map() map[string]interface{} {
+	return map[string]interface{}{
+		"wildcard": map[string]interface{}{q.field: q.value},
+	}
+}
+
+type FuzzyQuery struct {
+	field     string
+	value     string
+	fuzziness string
+}
+
+func NewFuzzyQuery(field, value string) *FuzzyQuery {
+	return &FuzzyQuery{field: field, value: value}
+}
+
+// Fuzziness sets the allowed edit distance, e.g. "AUTO" or "2".
+func (q *FuzzyQuery) Fuzziness(fuzziness string) *FuzzyQuery {
+	q.fuzziness = fuzziness
+	return q
+}
+
+func (q *FuzzyQuery) Map() map[string]interface{} {
+	options := map[string]interface{}{"value": q.value}
+	if q.fuzziness != "" {
+		options["fuzziness"] = q.fuzziness
+	}
+	return map[string]interface{}{
+		"fuzzy": map[string]interface{}{q.field: options},
+	}
+}
+
+type NestedQuery struct {
+	path      string
+	query     QueryClause
+	scoreMode string
+}
+
+func NewNestedQuery(path string, query QueryClause) *NestedQuery {
+	return &NestedQuery{path: path, query: query}
+}
+
+func (q *NestedQuery) ScoreMode(scoreMode string) *NestedQuery {
+	q.scoreMode = scoreMode
+	return q
+}
+
+func (q *NestedQuery) Map() map[string]interface{} {
+	options := map[string]interface{}{
+		"path":  q.path,
+		"query": q.query.Map(),
+	}
+	if q.scoreMode != "" {
+		options["score_mode"] = q.scoreMode
+	}
+	return map[string]interface{}{"nested": options}
+}
+
+type BoolQuery struct {
+	must               []QueryClause
+	should             []QueryClause
+	filter             []QueryClause
+	mustNot            []QueryClause
+	minimumShouldMatch interface{}
+}
+
+func NewBoolQuery() *BoolQuery {
+	return &BoolQuery{}
+}
+
+func (q *BoolQuery) Must(clauses ...QueryClause) *BoolQuery {
+	q.must = append(q.must, clauses...)
+	return q
+}
+
+func (q *BoolQuery) Should(clauses ...QueryClause) *BoolQuery {
+	q.should = append(q.should, clauses...)
+	return q
+}
+
+func (q *BoolQuery) Filter(clauses ...QueryClause) *BoolQuery {
+	q.filter = append(q.filter, clauses...)
+	return q
+}
+
+func (q *BoolQuery) MustNot(clauses ...QueryClause) *BoolQuery {
+	q.mustNot = append(q.mustNot, clauses...)
+	return q
+}
+
+// MinimumShouldMatch accepts a count (2) or a percentage ("75%").
+func (q *BoolQuery) MinimumShouldMatch(value interface{}) *BoolQuery {
+	q.minimumShouldMatch = value
+	return q
+}
+
+func (q *BoolQuery) Map() map[string]interface{} {
+	options := map[string]interface{}{}
+	for name, clauses := range map[string][]QueryClause{
+		"must":     q.must,
+		"should":   q.should,
+		"filter":   q.filter,
+		"must_not": q.mustNot,
+	} {
+		if len(clauses) == 0 {
+			continue
+		}
+		mapped := make([]map[string]interface{}, len(clauses))
+		for i, clause := range clauses {
+			mapped[i] = clause.Map()
+		}
+		options[name] = mapped
+	}
+	if q.minimumShouldMatch != nil {
+		options["minimum_should_match"] = q.minimumShouldMatch
+	}
+	return map[string]interface{}{"bool": options}
+}
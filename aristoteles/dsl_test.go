@@ -0,0 +1,130 @@
+package aristoteles
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func toJSON(t *testing.T, v interface{}) string {
+	t.Helper()
+	data, err := json.Marshal(v)
+	assert.Nil(t, err)
+	return string(data)
+}
+
+func TestSearchRequestBuilder(t *testing.T) {
+	builder := NewBuilderImpl()
+
+	t.Run("EquivalentToMatchQuery", func(t *testing.T) {
+		expected := builder.MatchQuery("greek", "πόλεμος")
+		sut := NewSearch().Query(NewMatchPhraseQuery("greek", "πόλεμος")).Build()
+		assert.JSONEq(t, toJSON(t, expected), toJSON(t, sut))
+	})
+
+	t.Run("EquivalentToMatchAll", func(t *testing.T) {
+		sut := builder.Search().Query(NewMatchAllQuery()).Build()
+		assert.JSONEq(t, toJSON(t, builder.MatchAll()), toJSON(t, sut))
+	})
+
+	t.Run("EquivalentToMultipleMatch", func(t *testing.T) {
+		expected := builder.MultipleMatch([]map[string]string{{"author": "herodotos"}, {"book": "1"}})
+		sut := NewSearch().Query(NewBoolQuery().Must(
+			NewMatchQuery("author", "herodotos"),
+			NewMatchQuery("book", "1"),
+		)).Build()
+		assert.JSONEq(t, toJSON(t, expected), toJSON(t, sut))
+	})
+
+	t.Run("EquivalentToMultiMatchWithGram", func(t *testing.T) {
+		field := "greek"
+		expected := builder.MultiMatchWithGram("λογ", field)
+		sut := NewSearch().Size(15).Query(
+			NewMultiMatchQuery("λογ", field, fmt.Sprintf("%s._2gram", field), fmt.Sprintf("%s._3gram", field)).Type("bool_prefix"),
+		).Build()
+		assert.JSONEq(t, toJSON(t, expected), toJSON(t, sut))
+	})
+
+	t.Run("EquivalentToFilteredAggregate", func(t *testing.T) {
+		expected := builder.FilteredAggregate("author", "herodotos", "books", "book")
+		sut := NewSearch().Size(0).
+			Query(NewMatchPhraseQuery("author", "herodotos")).
+			TermsAggregation("books", "book", 500).
+			Build()
+		assert.JSONEq(t, toJSON(t, expected), toJSON(t, sut))
+	})
+
+	t.Run("FullRequest", func(t *testing.T) {
+		sut := NewSearch().
+			Query(NewBoolQuery().
+				Must(NewMatchQuery("greek", "λόγος").Operator("and").Fuzziness("AUTO")).
+				Should(NewPrefixQuery("greek", "λογ"), NewWildcardQuery("english", "wor*"), NewFuzzyQuery("greek", "λογος").Fuzziness("2")).
+				Filter(NewTermQuery("author", "herodotos"), NewTermsQuery("book", 1, 2), NewRangeQuery("chapter").Gte(1).Lt(10)).
+				MustNot(NewNestedQuery("translations", NewTermQuery("translations.language", "de")).ScoreMode("avg")).
+				MinimumShouldMatch(1)).
+			From(10).
+			Size(20).
+			Sort("book", "asc").
+			SortWithMode("chapter", "desc", "max").
+			SortByScore().
+			Source("greek", "english").
+			SourceExcludes("perseusTextLink").
+			Highlight(NewHighlight("greek").Tags("<em>", "</em>")).
+			Build()
+
+		expected := `{
+			"query": {"bool": {
+				"must": [{"match": {"greek": {"query": "λόγος", "operator": "and", "fuzziness": "AUTO"}}}],
+				"should": [
+					{"prefix": {"greek": "λογ"}},
+					{"wildcard": {"english": "wor*"}},
+					{"fuzzy": {"greek": {"value": "λογος", "fuzziness": "2"}}}
+				],
+				"filter": [
+					{"term": {"author": "herodotos"}},
+					{"terms": {"book": [1, 2]}},
+					{"range": {"chapter": {"gte": 1, "lt": 10}}}
+				],
+				"must_not": [{"nested": {"path": "translations", "score_mode": "avg", "query": {"term": {"translations.language": "de"}}}}],
+				"minimum_should_match": 1
+			}},
+			"from": 10,
+			"size": 20,
+			"sort": [{"book": {"order": "asc"}}, {"chapter": {"order": "desc", "mode": "max"}}, "_score"],
+			"_source": {"includes": ["greek", "english"], "excludes": ["perseusTextLink"]},
+			"highlight": {"fields": {"greek": {}}, "pre_tags": ["<em>"], "post_tags": ["</em>"]}
+		}`
+		assert.JSONEq(t, expected, toJSON(t, sut))
+	})
+
+	t.Run("SourceFiltering", func(t *testing.T) {
+		assert.JSONEq(t, `{"_source": ["greek"]}`, toJSON(t, NewSearch().Source("greek").Build()))
+		assert.JSONEq(t, `{"_source": false}`, toJSON(t, NewSearch().NoSource().Build()))
+	})
+
+	t.Run("AcceptedByMatch", func(t *testing.T) {
+		testClient, err := NewMockClient("dionysosMultiMatch", 200)
+		assert.Nil(t, err)
+
+		body := testClient.Builder().Search().
+			Query(NewBoolQuery().Should(NewMatchQuery("greek", "ἔχω"), NewMatchQuery("original", "ἔχω"))).
+			Size(10).
+			Build()
+
+		sut, err := testClient.Query().Match("dictionary", body)
+		assert.Nil(t, err)
+		assert.Equal(t, int64(2), sut.Hits.Total.Value)
+	})
+
+	t.Run("AcceptedByMatchAggregate", func(t *testing.T) {
+		testClient, err := NewMockClient("aggregate", 200)
+		assert.Nil(t, err)
+
+		body := NewSearch().Size(0).TermsAggregation("authors", "author", 500).Build()
+		sut, err := testClient.Query().MatchAggregate("text", body)
+		assert.Nil(t, err)
+		assert.NotNil(t, sut)
+	})
+}
@@ -2,6 +2,7 @@ package aristoteles
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
@@ -10,6 +11,109 @@ import (
 	"github.com/elastic/go-elasticsearch/v9/esapi"
 )
 
+// ErrorKind classifies an Elasticsearch error by its type and status code.
+type ErrorKind string
+
+const (
+	KindUnknown         ErrorKind = "unknown"
+	KindNotFound        ErrorKind = "not_found"
+	KindConflict        ErrorKind = "conflict"
+	KindAlreadyExists   ErrorKind = "already_exists"
+	KindUnauthorized    ErrorKind = "unauthorized"
+	KindForbidden       ErrorKind = "forbidden"
+	KindMappingParse    ErrorKind = "mapping_parse"
+	KindBadRequest      ErrorKind = "bad_request"
+	KindShardFailure    ErrorKind = "shard_failure"
+	KindTooManyRequests ErrorKind = "too_many_requests"
+	KindTimeout         ErrorKind = "timeout"
+	KindUnavailable     ErrorKind = "unavailable"
+	KindServer          ErrorKind = "server"
+)
+
+var errorKindsByType = map[string]ErrorKind{
+	"index_not_found_exception":               KindNotFound,
+	"resource_not_found_exception":            KindNotFound,
+	"document_missing_exception":              KindNotFound,
+	"version_conflict_engine_exception":       KindConflict,
+	"resource_already_exists_exception":       KindAlreadyExists,
+	"mapper_parsing_exception":                KindMappingParse,
+	"document_parsing_exception":              KindMappingParse,
+	"strict_dynamic_mapping_exception":        KindMappingParse,
+	"illegal_argument_exception":              KindBadRequest,
+	"parsing_exception":                       KindBadRequest,
+	"x_content_parse_exception":               KindBadRequest,
+	"action_request_validation_exception":     KindBadRequest,
+	"search_phase_execution_exception":        KindShardFailure,
+	"es_rejected_execution_exception":         KindTooManyRequests,
+	"circuit_breaking_exception":              KindTooManyRequests,
+	"timeout_exception":                       KindTimeout,
+	"receive_timeout_transport_exception":     KindTimeout,
+	"process_cluster_event_timeout_exception": KindTimeout,
+	"cluster_block_exception":                 KindUnavailable,
+	"no_shard_available_action_exception":     KindUnavailable,
+	"unavailable_shards_exception":            KindUnavailable,
+	"node_not_connected_exception":            KindUnavailable,
+}
+
+// classifyError maps an error type to a kind, falling back to the status code
+// for unknown types and for responses without a body.
+func classifyError(status int, errorType string) ErrorKind {
+	if kind, ok := errorKindsByType[errorType]; ok {
+		return kind
+	}
+
+	switch {
+	case status == http.StatusNotFound:
+		return KindNotFound
+	case status == http.StatusConflict:
+		return KindConflict
+	case status == http.StatusUnauthorized:
+		return KindUnauthorized
+	case status == http.StatusForbidden:
+		return KindForbidden
+	case status == http.StatusTooManyRequests:
+		return KindTooManyRequests
+	case status == http.StatusRequestTimeout || status == http.StatusGatewayTimeout:
+		return KindTimeout
+	case status == http.StatusBadGateway || status == http.StatusServiceUnavailable:
+		return KindUnavailable
+	case status == http.StatusBadRequest:
+		return KindBadRequest
+	case status >= 500:
+		return KindServer
+	default:
+		return KindUnknown
+	}
+}
+
+// ErrorCause is an error object as Elasticsearch returns it, both for a failed
+// request and for a failed item in a bulk response.
+type ErrorCause struct {
+	Type         string         `json:"type"`
+	Reason       string         `json:"reason"`
+	Index        string         `json:"index,omitempty"`
+	Cause        *ErrorCause    `json:"caused_by,omitempty"`
+	RootCause    []ErrorCause   `json:"root_cause,omitempty"`
+	FailedShards []ShardFailure `json:"failed_shards,omitempty"`
+}
+
+// ShardFailure is a single shard that failed a search or write.
+type ShardFailure struct {
+	Shard  int        `json:"shard"`
+	Index  string     `json:"index"`
+	Node   string     `json:"node"`
+	Reason ErrorCause `json:"reason"`
+}
+
+func (e *ErrorCause) Error() string {
+	return fmt.Sprintf("%s: %s", e.Type, e.Reason)
+}
+
+// Kind classifies the error by its type alone.
+func (e *ErrorCause) Kind() ErrorKind {
+	return classifyError(0, e.Type)
+}
+
 type ElasticError struct {
 	Operation  string
 	StatusCode int
@@ -17,6 +121,14 @@ type ElasticError struct {
 	Took       *int64
 	Detail     interface{}
 	Body       string
+
+	// Kind, Type and Reason are taken from the top level error, RootCause and
+	// ShardFailures from its root_cause and failed_shards.
+	Kind          ErrorKind
+	Type          string
+	Reason        string
+	RootCause     []ErrorCause
+	ShardFailures []ShardFailure
 }
 
 func (e *ElasticError) Error() string {
@@ -75,9 +187,84 @@ func newElasticErrorFromBody(operation string, res *esapi.Response, body []byte)
 		}
 	}
 
+	var envelope struct {
+		Error json.RawMessage `json:"error"`
+	}
+	if json.Unmarshal(body, &envelope) == nil && len(envelope.Error) > 0 {
+		var cause ErrorCause
+		if json.Unmarshal(envelope.Error, &cause) == nil {
+			errResp.Type = cause.Type
+			errResp.Reason = cause.Reason
+			errResp.RootCause = cause.RootCause
+			errResp.ShardFailures = cause.FailedShards
+		} else {
+			// older or proxied responses carry the error as a plain string
+			_ = json.Unmarshal(envelope.Error, &errResp.Reason)
+		}
+	}
+	errResp.Kind = classifyError(errResp.StatusCode, errResp.Type)
+
 	return errResp
 }
 
+// ErrorKindOf returns the kind of an *ElasticError or *ErrorCause in err's
+// chain, or KindUnknown.
+func ErrorKindOf(err error) ErrorKind {
+	var elasticErr *ElasticError
+	if errors.As(err, &elasticErr) {
+		return elasticErr.Kind
+	}
+
+	var cause *ErrorCause
+	if errors.As(err, &cause) {
+		return cause.Kind()
+	}
+
+	return KindUnknown
+}
+
+// IsNotFound reports a missing index or document.
+func IsNotFound(err error) bool {
+	return ErrorKindOf(err) == KindNotFound
+}
+
+// IsConflict reports a version conflict, e.g. a failed if_seq_no check or a
+// create of an existing document id.
+func IsConflict(err error) bool {
+	return ErrorKindOf(err) == KindConflict
+}
+
+// IsUnauthorized reports failed authentication (401) or missing privileges (403).
+func IsUnauthorized(err error) bool {
+	kind := ErrorKindOf(err)
+	return kind == KindUnauthorized || kind == KindForbidden
+}
+
+// IsMappingError reports a document that does not fit the index mapping.
+func IsMappingError(err error) bool {
+	return ErrorKindOf(err) == KindMappingParse
+}
+
+// IsShardFailure reports a search that failed on its shards.
+func IsShardFailure(err error) bool {
+	return ErrorKindOf(err) == KindShardFailure
+}
+
+// IsRetryable reports errors that may succeed when the request is sent again:
+// rejections, timeouts, unavailable nodes and open circuits.
+func IsRetryable(err error) bool {
+	if errors.Is(err, ErrCircuitOpen) || errors.Is(err, errAttemptTimeout) {
+		return true
+	}
+
+	switch ErrorKindOf(err) {
+	case KindTooManyRequests, KindTimeout, KindUnavailable:
+		return true
+	default:
+		return false
+	}
+}
+
 func toInt(raw interface{}) (int, bool) {
 	switch v := raw.(type) {
 	case float64:
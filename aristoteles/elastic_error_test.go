@@ -0,0 +1,92 @@
+package aristoteles
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestElasticErrorKinds(t *testing.T) {
+	index := "dictionary"
+	query := NewBuilderImpl().MatchAll()
+
+	tests := []struct {
+		name      string
+		fixture   string
+		status    int
+		kind      ErrorKind
+		predicate func(error) bool
+		retryable bool
+	}{
+		{name: "NotFound", fixture: "deleteIndex404", status: 404, kind: KindNotFound, predicate: IsNotFound},
+		{name: "Unauthorized", fixture: "authenticationError401", status: 401, kind: KindUnauthorized, predicate: IsUnauthorized},
+		{name: "Forbidden", fixture: "authorizationError403", status: 403, kind: KindForbidden, predicate: IsUnauthorized},
+		{name: "TooManyRequests", fixture: "tooManyRequests429", status: 429, kind: KindTooManyRequests, predicate: IsRetryable, retryable: true},
+		{name: "ShardFailure", fixture: "shardFailure", status: 500, kind: KindShardFailure, predicate: IsShardFailure},
+		{name: "BodyWithoutError", fixture: "malformed", status: 503, kind: KindUnavailable, predicate: IsRetryable, retryable: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			testClient, err := NewMockClient(tt.fixture, tt.status)
+			assert.Nil(t, err)
+
+			_, err = testClient.Query().Match(index, query)
+			assert.NotNil(t, err)
+			assert.Equal(t, tt.kind, ErrorKindOf(err))
+			assert.True(t, tt.predicate(err))
+			assert.Equal(t, tt.retryable, IsRetryable(err))
+			assert.False(t, IsConflict(err))
+		})
+	}
+
+	t.Run("ParsesCauses", func(t *testing.T) {
+		testClient, err := NewMockClient("shardFailure", 500)
+		assert.Nil(t, err)
+
+		_, err = testClient.Query().Match(index, query)
+		var elasticErr *ElasticError
+		assert.True(t, errors.As(err, &elasticErr))
+		assert.Equal(t, "search_phase_execution_exception", elasticErr.Type)
+		assert.Equal(t, "all shards failed", elasticErr.Reason)
+		assert.Len(t, elasticErr.RootCause, 1)
+		assert.Equal(t, "illegal_state_exception", elasticErr.RootCause[0].Type)
+		assert.Len(t, elasticErr.ShardFailures, 1)
+		assert.Equal(t, -1, elasticErr.ShardFailures[0].Shard)
+		assert.Contains(t, elasticErr.ShardFailures[0].Reason.Reason, "is not available")
+	})
+
+	t.Run("Conflict", func(t *testing.T) {
+		testClient, err := NewFakeClient(nil)
+		assert.Nil(t, err)
+
+		_, err = testClient.Document().CreateWithId(index, "logos", []byte(`{"greek":"λόγος"}`))
+		assert.Nil(t, err)
+		_, err = testClient.Document().CreateWithId(index, "logos", []byte(`{"greek":"λόγος"}`))
+		assert.True(t, IsConflict(fmt.Errorf("wrapped: %w", err)))
+		assert.False(t, IsNotFound(err))
+	})
+
+	t.Run("BulkResponseItems", func(t *testing.T) {
+		testClient, err := NewMockClient("bulkPartialTooManyRequests", 200)
+		assert.Nil(t, err)
+
+		bulk, err := testClient.Document().Bulk(bytes.Buffer{}, index)
+		assert.Nil(t, err)
+		assert.True(t, bulk.Errors)
+		assert.Len(t, bulk.Items, 2)
+
+		assert.Nil(t, bulk.Items[0].Index.Err())
+		assert.Equal(t, "es_rejected_execution_exception", bulk.Items[1].Index.Error.Type)
+		assert.True(t, IsRetryable(bulk.Items[1].Index.Err()))
+	})
+
+	t.Run("OtherErrors", func(t *testing.T) {
+		assert.Equal(t, KindUnknown, ErrorKindOf(errors.New("plain")))
+		assert.False(t, IsNotFound(nil))
+		assert.True(t, IsRetryable(ErrCircuitOpen))
+	})
+}
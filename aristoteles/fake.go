@@ -0,0 +1,703 @@
+package aristoteles
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"path"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/elastic/go-elasticsearch/v9"
+)
+
+// FakeTransport is a stateful, in-memory stand-in for an Elasticsearch
+// cluster. Unlike the fixture based MockTransport it keeps the documents it is
+// sent, so a document written with CreateWithId can be read back with GetById
+// or found with Match. Writes are visible immediately, refresh is a no-op.
+//
+// Queries support match_all, match, match_phrase, match_phrase_prefix,
+// multi_match, term, terms, ids, exists, prefix, range and bool. Text queries
+// lowercase and split on anything that is not a letter or digit; term queries
+// compare the stored value exactly, as on a keyword field.
+type FakeTransport struct {
+	mu      sync.Mutex
+	indices map[string]*fakeIndex
+	scrolls map[string]*fakeScroll
+	nextID  int64
+}
+
+type fakeIndex struct {
+	settings map[string]interface{}
+	mappings map[string]interface{}
+	docs     map[string]*fakeDocument
+	order    []string
+	seqNo    int64
+}
+
+type fakeDocument struct {
+	source  map[string]interface{}
+	version int64
+	seqNo   int64
+}
+
+const fakePrimaryTerm = 1
+
+func NewFakeTransport() *FakeTransport {
+	return &FakeTransport{
+		indices: make(map[string]*fakeIndex),
+		scrolls: make(map[string]*fakeScroll),
+	}
+}
+
+func CreateFakeClient(fake *FakeTransport) (*elasticsearch.Client, error) {
+	return elasticsearch.NewClient(elasticsearch.Config{
+		Transport: fake,
+	})
+}
+
+type fakeResponse struct {
+	status int
+	body   interface{}
+}
+
+func fakeOK(body interface{}) fakeResponse {
+	return fakeResponse{status: http.StatusOK, body: body}
+}
+
+func fakeError(status int, errorType, reason string) fakeResponse {
+	cause := map[string]interface{}{"type": errorType, "reason": reason}
+	return fakeResponse{status: status, body: map[string]interface{}{
+		"error": map[string]interface{}{
+			"root_cause": []interface{}{cause},
+			"type":       errorType,
+			"reason":     reason,
+		},
+		"status": status,
+	}}
+}
+
+func fakeIndexNotFound(index string) fakeResponse {
+	return fakeError(http.StatusNotFound, "index_not_found_exception", fmt.Sprintf("no such index [%s]", index))
+}
+
+func (f *FakeTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	var body []byte
+	if req.Body != nil {
+		var err error
+		body, err = io.ReadAll(req.Body)
+		req.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	f.mu.Lock()
+	res := f.route(req.Method, req.URL, body)
+	f.mu.Unlock()
+
+	header := http.Header{
+		"X-Elastic-Product": []string{"Elasticsearch"},
+		"Content-Type":      []string{"application/json"},
+	}
+	if req.Method == http.MethodHead {
+		return &http.Response{StatusCode: res.status, Header: header, Body: http.NoBody, Request: req}, nil
+	}
+
+	encoded, err := json.Marshal(res.body)
+	if err != nil {
+		return nil, err
+	}
+	return &http.Response{
+		StatusCode: res.status,
+		Header:     header,
+		Body:       io.NopCloser(bytes.NewReader(encoded)),
+		Request:    req,
+	}, nil
+}
+
+func (f *FakeTransport) route(method string, u *url.URL, body []byte) fakeResponse {
+	var segments []string
+	for _, segment := range strings.Split(strings.Trim(u.EscapedPath(), "/"), "/") {
+		if segment == "" {
+			continue
+		}
+		if unescaped, err := url.PathUnescape(segment); err == nil {
+			segment = unescaped
+		}
+		segments = append(segments, segment)
+	}
+	params := fakeParams{}
+	for name, values := range u.Query() {
+		params[name] = values[0]
+	}
+
+	switch {
+	case len(segments) == 0:
+		return fakeOK(map[string]interface{}{
+			"name":         "aristoteles-fake",
+			"cluster_name": "aristoteles-fake",
+			"version":      map[string]interface{}{"number": "9.0.0"},
+			"tagline":      "You Know, for Search",
+		})
+	case segments[0] == "_bulk":
+		return f.bulk("", body)
+	case segments[0] == "_search" && len(segments) > 1 && segments[1] == "scroll":
+		if len(segments) > 2 {
+			params["scroll_id"] = segments[2]
+		}
+		return f.scroll(method, params, body)
+	case segments[0] == "_search":
+		return f.search("*", params, body)
+	case segments[0] == "_count":
+		return f.count("*", body)
+	case strings.HasPrefix(segments[0], "_"):
+		return f.unsupported(method, segments)
+	}
+
+	index := segments[0]
+	if len(segments) == 1 {
+		switch method {
+		case http.MethodPut:
+			return f.createIndex(index, body)
+		case http.MethodDelete:
+			return f.deleteIndex(index)
+		case http.MethodGet:
+			return f.getIndex(index)
+		case http.MethodHead:
+			if len(f.resolve(index)) == 0 {
+				return fakeResponse{status: http.StatusNotFound}
+			}
+			return fakeResponse{status: http.StatusOK}
+		}
+		return f.unsupported(method, segments)
+	}
+
+	endpoint := segments[1]
+	id := ""
+	if len(segments) > 2 {
+		id = segments[2]
+	}
+
+	switch {
+	case endpoint == "_doc" && id == "" && method == http.MethodPost:
+		return f.indexDocument(index, "", params, body, false)
+	case endpoint == "_doc" && id != "":
+		switch method {
+		case http.MethodPut, http.MethodPost:
+			return f.indexDocument(index, id, params, body, params.get("op_type") == "create")
+		case http.MethodGet, http.MethodHead:
+			return f.getDocument(index, id)
+		case http.MethodDelete:
+			return f.deleteDocument(index, id, params)
+		}
+	case endpoint == "_create":
+		if id == "" {
+			return fakeError(http.StatusBadRequest, "illegal_argument_exception", "a document id is required for _create")
+		}
+		return f.indexDocument(index, id, params, body, true)
+	case endpoint == "_update" && id != "":
+		return f.updateDocument(index, id, params, body)
+	case endpoint == "_search":
+		return f.search(index, params, body)
+	case endpoint == "_count":
+		return f.count(index, body)
+	case endpoint == "_bulk":
+		return f.bulk(index, body)
+	case endpoint == "_refresh":
+		if len(f.resolve(index)) == 0 {
+			return fakeIndexNotFound(index)
+		}
+		return fakeOK(map[string]interface{}{"_shards": fakeShards()})
+	}
+
+	return f.unsupported(method, segments)
+}
+
+func (f *FakeTransport) unsupported(method string, segments []string) fakeResponse {
+	return fakeError(http.StatusBadRequest, "illegal_argument_exception",
+		fmt.Sprintf("aristoteles fake does not support %s /%s", method, strings.Join(segments, "/")))
+}
+
+// resolve expands a comma separated list of index names and wildcards into
+// the existing indices it refers to.
+func (f *FakeTransport) resolve(expression string) []string {
+	seen := make(map[string]bool)
+	var names []string
+	for _, pattern := range strings.Split(expression, ",") {
+		if pattern == "_all" {
+			pattern = "*"
+		}
+		for name := range f.indices {
+			if ok, _ := path.Match(pattern, name); ok && !seen[name] {
+				seen[name] = true
+				names = append(names, name)
+			}
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+func (f *FakeTransport) createIndex(index string, body []byte) fakeResponse {
+	if _, ok := f.indices[index]; ok {
+		return fakeError(http.StatusBadRequest, "resource_already_exists_exception", fmt.Sprintf("index [%s] already exists", index))
+	}
+
+	var request struct {
+		Settings map[string]interface{} `json:"settings"`
+		Mappings map[string]interface{} `json:"mappings"`
+	}
+	if len(bytes.TrimSpace(body)) > 0 {
+		if err := json.Unmarshal(body, &request); err != nil {
+			return fakeError(http.StatusBadRequest, "parse_exception", err.Error())
+		}
+	}
+
+	f.indices[index] = newFakeIndex(request.Settings, request.Mappings)
+	return fakeOK(map[string]interface{}{"acknowledged": true, "shards_acknowledged": true, "index": index})
+}
+
+func newFakeIndex(settings, mappings map[string]interface{}) *fakeIndex {
+	if settings == nil {
+		settings = map[string]interface{}{}
+	}
+	if mappings == nil {
+		mappings = map[string]interface{}{}
+	}
+	return &fakeIndex{settings: settings, mappings: mappings, docs: make(map[string]*fakeDocument)}
+}
+
+func (f *FakeTransport) deleteIndex(index string) fakeResponse {
+	names := f.resolve(index)
+	if len(names) == 0 {
+		return fakeIndexNotFound(index)
+	}
+	for _, name := range names {
+		delete(f.indices, name)
+	}
+	return fakeOK(map[string]interface{}{"acknowledged": true})
+}
+
+func (f *FakeTransport) getIndex(index string) fakeResponse {
+	names := f.resolve(index)
+	if len(names) == 0 {
+		return fakeIndexNotFound(index)
+	}
+
+	response := make(map[string]interface{}, len(names))
+	for _, name := range names {
+		idx := f.indices[name]
+		response[name] = map[string]interface{}{
+			"aliases":  map[string]interface{}{},
+			"mappings": idx.mappings,
+			"settings": idx.settings,
+		}
+	}
+	return fakeOK(response)
+}
+
+// indexFor returns the index to write to, creating it like Elasticsearch's
+// automatic index creation does.
+func (f *FakeTransport) indexFor(index string) *fakeIndex {
+	idx, ok := f.indices[index]
+	if !ok {
+		idx = newFakeIndex(nil, nil)
+		f.indices[index] = idx
+	}
+	return idx
+}
+
+func (f *FakeTransport) indexDocument(index, id string, params fakeParams, body []byte, createOnly bool) fakeResponse {
+	var source map[string]interface{}
+	if err := json.Unmarshal(body, &source); err != nil {
+		return fakeError(http.StatusBadRequest, "mapper_parsing_exception", fmt.Sprintf("failed to parse: %s", err))
+	}
+
+	return f.write(index, id, params, createOnly, func(existing map[string]interface{}) (map[string]interface{}, fakeResponse) {
+		return source, fakeResponse{}
+	})
+}
+
+func (f *FakeTransport) write(index, id string, params fakeParams, createOnly bool, apply func(existing map[string]interface{}) (map[string]interface{}, fakeResponse)) fakeResponse {
+	if id == "" {
+		f.nextID++
+		id = fmt.Sprintf("fake-%d", f.nextID)
+	}
+
+	idx := f.indexFor(index)
+	existing, exists := idx.docs[id]
+	if exists && createOnly {
+		return fakeVersionConflict(id, "document already exists (current version ["+strconv.FormatInt(existing.version, 10)+"])")
+	}
+	if res, ok := checkSeqNo(id, existing, params); !ok {
+		return res
+	}
+
+	var current map[string]interface{}
+	if exists {
+		current = existing.source
+	}
+	source, failure := apply(current)
+	if failure.status != 0 {
+		return failure
+	}
+
+	idx.seqNo++
+	doc := &fakeDocument{source: source, version: 1, seqNo: idx.seqNo - 1}
+	result, status := "created", http.StatusCreated
+	if exists {
+		doc.version = existing.version + 1
+		result, status = "updated", http.StatusOK
+	} else {
+		idx.order = append(idx.order, id)
+	}
+	idx.docs[id] = doc
+
+	return fakeResponse{status: status, body: fakeWriteResult(index, id, doc, result)}
+}
+
+func fakeWriteResult(index, id string, doc *fakeDocument, result string) map[string]interface{} {
+	return map[string]interface{}{
+		"_index":        index,
+		"_id":           id,
+		"_version":      doc.version,
+		"result":        result,
+		"_shards":       map[string]interface{}{"total": 1, "successful": 1, "failed": 0},
+		"_seq_no":       doc.seqNo,
+		"_primary_term": fakePrimaryTerm,
+	}
+}
+
+func fakeVersionConflict(id, detail string) fakeResponse {
+	return fakeError(http.StatusConflict, "version_conflict_engine_exception", fmt.Sprintf("[%s]: version conflict, %s", id, detail))
+}
+
+func checkSeqNo(id string, existing *fakeDocument, params fakeParams) (fakeResponse, bool) {
+	ifSeqNo, hasSeqNo := params.int("if_seq_no")
+	ifPrimaryTerm, hasPrimaryTerm := params.int("if_primary_term")
+	if !hasSeqNo && !hasPrimaryTerm {
+		return fakeResponse{}, true
+	}
+	if existing == nil {
+		return fakeVersionConflict(id, fmt.Sprintf("required seqNo [%d], primary term [%d] but no document was found", ifSeqNo, ifPrimaryTerm)), false
+	}
+	if ifSeqNo != existing.seqNo || ifPrimaryTerm != fakePrimaryTerm {
+		return fakeVersionConflict(id, fmt.Sprintf("required seqNo [%d], primary term [%d]. current document has seqNo [%d] and primary term [%d]",
+			ifSeqNo, ifPrimaryTerm, existing.seqNo, fakePrimaryTerm)), false
+	}
+	return fakeResponse{}, true
+}
+
+func (f *FakeTransport) getDocument(index, id string) fakeResponse {
+	idx, ok := f.indices[index]
+	if !ok {
+		return fakeIndexNotFound(index)
+	}
+	doc, ok := idx.docs[id]
+	if !ok {
+		return fakeResponse{status: http.StatusNotFound, body: map[string]interface{}{"_index": index, "_id": id, "found": false}}
+	}
+
+	return fakeOK(map[string]interface{}{
+		"_index":        index,
+		"_id":           id,
+		"_version":      doc.version,
+		"_seq_no":       doc.seqNo,
+		"_primary_term": fakePrimaryTerm,
+		"found":         true,
+		"_source":       doc.source,
+	})
+}
+
+func (f *FakeTransport) deleteDocument(index, id string, params fakeParams) fakeResponse {
+	idx, ok := f.indices[index]
+	if !ok {
+		return fakeIndexNotFound(index)
+	}
+	doc, ok := idx.docs[id]
+	if res, ok := checkSeqNo(id, doc, params); !ok {
+		return res
+	}
+	if !ok {
+		idx.seqNo++
+		return fakeResponse{status: http.StatusNotFound, body: fakeWriteResult(index, id, &fakeDocument{version: 1, seqNo: idx.seqNo - 1}, "not_found")}
+	}
+
+	delete(idx.docs, id)
+	for i, existing := range idx.order {
+		if existing == id {
+			idx.order = append(idx.order[:i], idx.order[i+1:]...)
+			break
+		}
+	}
+	idx.seqNo++
+	deleted := &fakeDocument{version: doc.version + 1, seqNo: idx.seqNo - 1}
+	return fakeOK(fakeWriteResult(index, id, deleted, "deleted"))
+}
+
+type fakeUpdateRequest struct {
+	Doc         map[string]interface{} `json:"doc"`
+	DocAsUpsert bool                   `json:"doc_as_upsert"`
+	Upsert      map[string]interface{} `json:"upsert"`
+	Script      *struct {
+		Source string                 `json:"source"`
+		Params map[string]interface{} `json:"params"`
+	} `json:"script"`
+	ScriptedUpsert bool `json:"scripted_upsert"`
+}
+
+func (f *FakeTransport) updateDocument(index, id string, params fakeParams, body []byte) fakeResponse {
+	var request fakeUpdateRequest
+	if err := json.Unmarshal(body, &request); err != nil {
+		return fakeError(http.StatusBadRequest, "x_content_parse_exception", err.Error())
+	}
+	if request.Doc == nil && request.Script == nil {
+		return fakeError(http.StatusBadRequest, "action_request_validation_exception", "Validation Failed: 1: script or doc is missing;")
+	}
+
+	var existing *fakeDocument
+	if idx, ok := f.indices[index]; ok {
+		existing = idx.docs[id]
+	}
+	if existing == nil && request.Upsert == nil && !request.DocAsUpsert {
+		if _, ok := f.indices[index]; !ok {
+			return fakeIndexNotFound(index)
+		}
+		return fakeError(http.StatusNotFound, "document_missing_exception", fmt.Sprintf("[%s]: document missing", id))
+	}
+
+	noop := false
+	res := f.write(index, id, params, false, func(current map[string]interface{}) (map[string]interface{}, fakeResponse) {
+		if current == nil {
+			if request.DocAsUpsert {
+				return request.Doc, fakeResponse{}
+			}
+			if !request.ScriptedUpsert {
+				return request.Upsert, fakeResponse{}
+			}
+			current = request.Upsert
+		}
+
+		updated := deepCopy(current)
+		if request.Script != nil {
+			if err := runFakeScript(updated, request.Script.Source, request.Script.Params); err != nil {
+				return nil, fakeError(http.StatusBadRequest, "illegal_argument_exception", err.Error())
+			}
+		} else {
+			mergeSource(updated, request.Doc)
+		}
+		noop = reflect.DeepEqual(updated, current)
+		return updated, fakeResponse{}
+	})
+
+	if noop && existing != nil {
+		// a noop leaves the stored document untouched, undo the write
+		idx := f.indices[index]
+		idx.docs[id] = existing
+		idx.seqNo--
+		return fakeOK(fakeWriteResult(index, id, existing, "noop"))
+	}
+	return res
+}
+
+func mergeSource(target, patch map[string]interface{}) {
+	for key, value := range patch {
+		patchObject, isObject := value.(map[string]interface{})
+		targetObject, targetIsObject := target[key].(map[string]interface{})
+		if isObject && targetIsObject {
+			mergeSource(targetObject, patchObject)
+			continue
+		}
+		target[key] = value
+	}
+}
+
+func deepCopy(source map[string]interface{}) map[string]interface{} {
+	raw, _ := json.Marshal(source)
+	var copied map[string]interface{}
+	json.Unmarshal(raw, &copied)
+	return copied
+}
+
+// runFakeScript understands the painless statements this package sends:
+// ctx._source.<field>.add(params.<name>), .addAll(params.<name>),
+// ctx._source.<field> = params.<name> and ctx._source.<field> += params.<name>,
+// separated by semicolons.
+func runFakeScript(source map[string]interface{}, script string, params map[string]interface{}) error {
+	for _, statement := range strings.Split(script, ";") {
+		statement = strings.TrimSpace(statement)
+		if statement == "" {
+			continue
+		}
+		if !strings.HasPrefix(statement, "ctx._source.") {
+			return fmt.Errorf("aristoteles fake cannot run script [%s]", statement)
+		}
+		statement = strings.TrimPrefix(statement, "ctx._source.")
+
+		param := func(expression string) (interface{}, error) {
+			expression = strings.TrimSpace(expression)
+			if !strings.HasPrefix(expression, "params.") {
+				return nil, fmt.Errorf("aristoteles fake cannot run script expression [%s]", expression)
+			}
+			value, ok := params[strings.TrimPrefix(expression, "params.")]
+			if !ok {
+				return nil, fmt.Errorf("missing script param [%s]", expression)
+			}
+			return value, nil
+		}
+
+		switch {
+		case strings.Contains(statement, ".addAll(") && strings.HasSuffix(statement, ")"):
+			field, arg, _ := strings.Cut(strings.TrimSuffix(statement, ")"), ".addAll(")
+			value, err := param(arg)
+			if err != nil {
+				return err
+			}
+			items, _ := value.([]interface{})
+			list, _ := source[field].([]interface{})
+			source[field] = append(list, items...)
+		case strings.Contains(statement, ".add(") && strings.HasSuffix(statement, ")"):
+			field, arg, _ := strings.Cut(strings.TrimSuffix(statement, ")"), ".add(")
+			value, err := param(arg)
+			if err != nil {
+				return err
+			}
+			list, ok := source[field].([]interface{})
+			if !ok {
+				return fmt.Errorf("cannot invoke add on [%s]: not a list", field)
+			}
+			source[field] = append(list, value)
+		case strings.Contains(statement, "+="):
+			field, arg, _ := strings.Cut(statement, "+=")
+			field = strings.TrimSpace(field)
+			value, err := param(arg)
+			if err != nil {
+				return err
+			}
+			current, _ := source[field].(float64)
+			increment, ok := value.(float64)
+			if !ok {
+				return fmt.Errorf("cannot add non numeric param to [%s]", field)
+			}
+			source[field] = current + increment
+		case strings.Contains(statement, "="):
+			field, arg, _ := strings.Cut(statement, "=")
+			value, err := param(arg)
+			if err != nil {
+				return err
+			}
+			source[strings.TrimSpace(field)] = value
+		default:
+			return fmt.Errorf("aristoteles fake cannot run script [ctx._source.%s]", statement)
+		}
+	}
+	return nil
+}
+
+func (f *FakeTransport) bulk(defaultIndex string, body []byte) fakeResponse {
+	scanner := bufio.NewScanner(bytes.NewReader(body))
+	scanner.Buffer(make([]byte, 0, 64*1024), 64*1024*1024)
+
+	var items []interface{}
+	hasErrors := false
+	for scanner.Scan() {
+		line := bytes.TrimSpace(scanner.Bytes())
+		if len(line) == 0 {
+			continue
+		}
+
+		var action map[string]struct {
+			Index         string `json:"_index"`
+			ID            string `json:"_id"`
+			IfSeqNo       *int64 `json:"if_seq_no"`
+			IfPrimaryTerm *int64 `json:"if_primary_term"`
+		}
+		if err := json.Unmarshal(line, &action); err != nil || len(action) != 1 {
+			return fakeError(http.StatusBadRequest, "illegal_argument_exception", fmt.Sprintf("Malformed action/metadata line [%s]", line))
+		}
+
+		for name, meta := range action {
+			index := meta.Index
+			if index == "" {
+				index = defaultIndex
+			}
+			params := fakeParams{}
+			if meta.IfSeqNo != nil {
+				params["if_seq_no"] = strconv.FormatInt(*meta.IfSeqNo, 10)
+			}
+			if meta.IfPrimaryTerm != nil {
+				params["if_primary_term"] = strconv.FormatInt(*meta.IfPrimaryTerm, 10)
+			}
+
+			var source []byte
+			if name != "delete" {
+				if !scanner.Scan() {
+					return fakeError(http.StatusBadRequest, "illegal_argument_exception", "The bulk request must be terminated by a newline [\\n]")
+				}
+				source = append([]byte(nil), scanner.Bytes()...)
+			}
+
+			var res fakeResponse
+			switch name {
+			case "index":
+				res = f.indexDocument(index, meta.ID, params, source, false)
+			case "create":
+				res = f.indexDocument(index, meta.ID, params, source, true)
+			case "update":
+				res = f.updateDocument(index, meta.ID, params, source)
+			case "delete":
+				res = f.deleteDocument(index, meta.ID, params)
+			default:
+				return fakeError(http.StatusBadRequest, "illegal_argument_exception", fmt.Sprintf("Malformed action/metadata line [%s], expected one of [create, delete, index, update] but found [%s]", line, name))
+			}
+
+			item := map[string]interface{}{"status": res.status}
+			if body, ok := res.body.(map[string]interface{}); ok {
+				if errBody, isError := body["error"].(map[string]interface{}); isError {
+					hasErrors = true
+					item["_index"] = index
+					item["_id"] = meta.ID
+					delete(errBody, "root_cause")
+					item["error"] = errBody
+				} else {
+					for key, value := range body {
+						item[key] = value
+					}
+				}
+			}
+			items = append(items, map[string]interface{}{name: item})
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return fakeError(http.StatusBadRequest, "illegal_argument_exception", err.Error())
+	}
+
+	return fakeOK(map[string]interface{}{"took": 1, "errors": hasErrors, "items": items})
+}
+
+func fakeShards() map[string]interface{} {
+	return map[string]interface{}{"total": 1, "successful": 1, "skipped": 0, "failed": 0}
+}
+
+type fakeParams map[string]string
+
+func (p fakeParams) get(name string) string {
+	return p[name]
+}
+
+func (p fakeParams) int(name string) (int64, bool) {
+	value, ok := p[name]
+	if !ok {
+		return 0, false
+	}
+	parsed, err := strconv.ParseInt(value, 10, 64)
+	return parsed, err == nil
+}
@@ -0,0 +1,631 @@
+package aristoteles
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+type fakeScroll struct {
+	hits  []map[string]interface{}
+	size  int
+	total int
+}
+
+type fakeHit struct {
+	index  string
+	id     string
+	source map[string]interface{}
+}
+
+func (f *FakeTransport) search(expression string, params fakeParams, body []byte) fakeResponse {
+	var request struct {
+		Query map[string]interface{} `json:"query"`
+		Size  *int                   `json:"size"`
+		From  *int                   `json:"from"`
+		Sort  interface{}            `json:"sort"`
+	}
+	if len(strings.TrimSpace(string(body))) > 0 {
+		if err := json.Unmarshal(body, &request); err != nil {
+			return fakeError(http.StatusBadRequest, "parsing_exception", err.Error())
+		}
+	}
+
+	names, missing := f.resolveForRead(expression)
+	if missing != "" {
+		return fakeIndexNotFound(missing)
+	}
+
+	hits, err := f.collect(names, request.Query)
+	if err != nil {
+		return fakeError(http.StatusBadRequest, "parsing_exception", err.Error())
+	}
+
+	var sortFields []fakeSortField
+	if raw := params.get("sort"); raw != "" {
+		for _, part := range strings.Split(raw, ",") {
+			field, order, _ := strings.Cut(part, ":")
+			sortFields = append(sortFields, fakeSortField{field: field, descending: order == "desc"})
+		}
+	} else if request.Sort != nil {
+		sortFields = parseFakeSort(request.Sort)
+	}
+	sortFakeHits(hits, sortFields)
+
+	size, from := 10, 0
+	if request.Size != nil {
+		size = *request.Size
+	}
+	if request.From != nil {
+		from = *request.From
+	}
+	if value, ok := params.int("size"); ok {
+		size = int(value)
+	}
+	if value, ok := params.int("from"); ok {
+		from = int(value)
+	}
+
+	rendered := make([]map[string]interface{}, 0, len(hits))
+	for _, hit := range hits {
+		rendered = append(rendered, map[string]interface{}{
+			"_index":  hit.index,
+			"_id":     hit.id,
+			"_score":  1.0,
+			"_source": hit.source,
+		})
+	}
+	if from > len(rendered) {
+		from = len(rendered)
+	}
+	rendered = rendered[from:]
+
+	page := rendered
+	if size < len(page) {
+		page = page[:size]
+	}
+
+	response := fakeSearchResponse(len(hits), page)
+	if params.get("scroll") != "" {
+		f.nextID++
+		scrollID := fmt.Sprintf("fake-scroll-%d", f.nextID)
+		f.scrolls[scrollID] = &fakeScroll{hits: rendered[len(page):], size: size, total: len(hits)}
+		response["_scroll_id"] = scrollID
+	}
+
+	return fakeOK(response)
+}
+
+func fakeSearchResponse(total int, page []map[string]interface{}) map[string]interface{} {
+	var maxScore interface{}
+	if len(page) > 0 {
+		maxScore = 1.0
+	}
+	return map[string]interface{}{
+		"took":      1,
+		"timed_out": false,
+		"_shards":   fakeShards(),
+		"hits": map[string]interface{}{
+			"total":     map[string]interface{}{"value": total, "relation": "eq"},
+			"max_score": maxScore,
+			"hits":      page,
+		},
+	}
+}
+
+func (f *FakeTransport) scroll(method string, params fakeParams, body []byte) fakeResponse {
+	var request struct {
+		ScrollID interface{} `json:"scroll_id"`
+	}
+	if len(strings.TrimSpace(string(body))) > 0 {
+		if err := json.Unmarshal(body, &request); err != nil {
+			return fakeError(http.StatusBadRequest, "parsing_exception", err.Error())
+		}
+	}
+
+	var ids []string
+	switch v := request.ScrollID.(type) {
+	case string:
+		ids = append(ids, v)
+	case []interface{}:
+		for _, id := range v {
+			ids = append(ids, fmt.Sprint(id))
+		}
+	}
+	if id := params.get("scroll_id"); id != "" {
+		ids = append(ids, strings.Split(id, ",")...)
+	}
+
+	if method == http.MethodDelete {
+		freed := 0
+		for _, id := range ids {
+			if _, ok := f.scrolls[id]; ok || id == "_all" {
+				freed++
+			}
+			delete(f.scrolls, id)
+		}
+		return fakeOK(map[string]interface{}{"succeeded": true, "num_freed": freed})
+	}
+
+	if len(ids) != 1 {
+		return fakeError(http.StatusBadRequest, "action_request_validation_exception", "Validation Failed: 1: scrollId is missing;")
+	}
+	state, ok := f.scrolls[ids[0]]
+	if !ok {
+		return fakeError(http.StatusNotFound, "search_context_missing_exception", fmt.Sprintf("No search context found for id [%s]", ids[0]))
+	}
+
+	page := state.hits
+	if state.size < len(page) {
+		page = page[:state.size]
+	}
+	state.hits = state.hits[len(page):]
+
+	// every page reports the total of the original search, like elasticsearch
+	response := fakeSearchResponse(state.total, page)
+	response["_scroll_id"] = ids[0]
+	return fakeOK(response)
+}
+
+func (f *FakeTransport) count(expression string, body []byte) fakeResponse {
+	var request struct {
+		Query map[string]interface{} `json:"query"`
+	}
+	if len(strings.TrimSpace(string(body))) > 0 {
+		if err := json.Unmarshal(body, &request); err != nil {
+			return fakeError(http.StatusBadRequest, "parsing_exception", err.Error())
+		}
+	}
+
+	names, missing := f.resolveForRead(expression)
+	if missing != "" {
+		return fakeIndexNotFound(missing)
+	}
+
+	hits, err := f.collect(names, request.Query)
+	if err != nil {
+		return fakeError(http.StatusBadRequest, "parsing_exception", err.Error())
+	}
+
+	return fakeOK(map[string]interface{}{"count": len(hits), "_shards": fakeShards()})
+}
+
+// resolveForRead resolves an index expression and reports the first concrete
+// name that does not exist, wildcards may match nothing.
+func (f *FakeTransport) resolveForRead(expression string) ([]string, string) {
+	for _, name := range strings.Split(expression, ",") {
+		if strings.Contains(name, "*") || name == "_all" {
+			continue
+		}
+		if _, ok := f.indices[name]; !ok {
+			return nil, name
+		}
+	}
+	return f.resolve(expression), ""
+}
+
+func (f *FakeTransport) collect(names []string, query map[string]interface{}) ([]fakeHit, error) {
+	var hits []fakeHit
+	for _, name := range names {
+		idx := f.indices[name]
+		for _, id := range idx.order {
+			source := idx.docs[id].source
+			matched, err := fakeMatches(query, id, source)
+			if err != nil {
+				return nil, err
+			}
+			if matched {
+				hits = append(hits, fakeHit{index: name, id: id, source: source})
+			}
+		}
+	}
+	return hits, nil
+}
+
+type fakeSortField struct {
+	field      string
+	descending bool
+}
+
+func parseFakeSort(raw interface{}) []fakeSortField {
+	var entries []interface{}
+	if list, ok := raw.([]interface{}); ok {
+		entries = list
+	} else {
+		entries = []interface{}{raw}
+	}
+
+	var fields []fakeSortField
+	for _, entry := range entries {
+		switch v := entry.(type) {
+		case string:
+			fields = append(fields, fakeSortField{field: v})
+		case map[string]interface{}:
+			for field, spec := range v {
+				order := ""
+				switch s := spec.(type) {
+				case string:
+					order = s
+				case map[string]interface{}:
+					order, _ = s["order"].(string)
+				}
+				fields = append(fields, fakeSortField{field: field, descending: order == "desc"})
+			}
+		}
+	}
+	return fields
+}
+
+func sortFakeHits(hits []fakeHit, fields []fakeSortField) {
+	if len(fields) == 0 {
+		return
+	}
+	sort.SliceStable(hits, func(a, b int) bool {
+		for _, field := range fields {
+			if field.field == "_score" || field.field == "_doc" {
+				continue
+			}
+			left, leftOk := firstValue(hits[a].source, field.field)
+			right, rightOk := firstValue(hits[b].source, field.field)
+			switch {
+			case !leftOk && !rightOk:
+				continue
+			case !leftOk:
+				return false
+			case !rightOk:
+				return true
+			}
+			cmp := compareValues(left, right)
+			if cmp == 0 {
+				continue
+			}
+			if field.descending {
+				return cmp > 0
+			}
+			return cmp < 0
+		}
+		return false
+	})
+}
+
+func fakeMatches(query map[string]interface{}, id string, source map[string]interface{}) (bool, error) {
+	if len(query) == 0 {
+		return true, nil
+	}
+	if len(query) != 1 {
+		return false, fmt.Errorf("query malformed, expected a single query type but found %d", len(query))
+	}
+
+	for queryType, rawBody := range query {
+		body, _ := rawBody.(map[string]interface{})
+		switch queryType {
+		case "match_all":
+			return true, nil
+		case "match_none":
+			return false, nil
+		case "bool":
+			return fakeBool(body, id, source)
+		case "ids":
+			values, _ := body["values"].([]interface{})
+			for _, value := range values {
+				if fmt.Sprint(value) == id {
+					return true, nil
+				}
+			}
+			return false, nil
+		case "exists":
+			field, _ := body["field"].(string)
+			_, ok := firstValue(source, field)
+			return ok, nil
+		case "multi_match":
+			text := fmt.Sprint(body["query"])
+			fields, _ := body["fields"].([]interface{})
+			for _, rawField := range fields {
+				field, _, _ := strings.Cut(fmt.Sprint(rawField), "^")
+				if matchText(fieldValues(source, field), text, "or") {
+					return true, nil
+				}
+			}
+			return false, nil
+		}
+
+		field, value, options, err := fieldQuery(queryType, body)
+		if err != nil {
+			return false, err
+		}
+		values := fieldValues(source, field)
+
+		switch queryType {
+		case "match":
+			operator, _ := options["operator"].(string)
+			return matchText(values, fmt.Sprint(value), strings.ToLower(operator)), nil
+		case "match_phrase":
+			return matchPhrase(values, fmt.Sprint(value), false), nil
+		case "match_phrase_prefix":
+			return matchPhrase(values, fmt.Sprint(value), true), nil
+		case "term":
+			return containsValue(values, value), nil
+		case "terms":
+			candidates, _ := value.([]interface{})
+			for _, candidate := range candidates {
+				if containsValue(values, candidate) {
+					return true, nil
+				}
+			}
+			return false, nil
+		case "prefix":
+			prefix := fmt.Sprint(value)
+			for _, v := range values {
+				if s, ok := v.(string); ok && strings.HasPrefix(s, prefix) {
+					return true, nil
+				}
+			}
+			return false, nil
+		case "range":
+			bounds, _ := value.(map[string]interface{})
+			for _, v := range values {
+				if inRange(v, bounds) {
+					return true, nil
+				}
+			}
+			return false, nil
+		}
+
+		return false, fmt.Errorf("unknown query [%s] for aristoteles fake", queryType)
+	}
+	return false, nil
+}
+
+// fieldQuery unpacks {"field": value} and {"field": {"query"|"value": value, ...}}.
+func fieldQuery(queryType string, body map[string]interface{}) (string, interface{}, map[string]interface{}, error) {
+	if len(body) != 1 {
+		return "", nil, nil, fmt.Errorf("[%s] query expects exactly one field", queryType)
+	}
+	for field, raw := range body {
+		if queryType == "terms" || queryType == "range" {
+			return field, raw, nil, nil
+		}
+		options, ok := raw.(map[string]interface{})
+		if !ok {
+			return field, raw, nil, nil
+		}
+		for _, key := range []string{"query", "value"} {
+			if value, ok := options[key]; ok {
+				return field, value, options, nil
+			}
+		}
+		return "", nil, nil, fmt.Errorf("[%s] query for [%s] has no query or value", queryType, field)
+	}
+	return "", nil, nil, nil
+}
+
+func fakeBool(body map[string]interface{}, id string, source map[string]interface{}) (bool, error) {
+	clauses := func(key string) []map[string]interface{} {
+		var out []map[string]interface{}
+		switch v := body[key].(type) {
+		case map[string]interface{}:
+			out = append(out, v)
+		case []interface{}:
+			for _, clause := range v {
+				if m, ok := clause.(map[string]interface{}); ok {
+					out = append(out, m)
+				}
+			}
+		}
+		return out
+	}
+
+	for _, key := range []string{"must", "filter"} {
+		for _, clause := range clauses(key) {
+			matched, err := fakeMatches(clause, id, source)
+			if err != nil || !matched {
+				return false, err
+			}
+		}
+	}
+	for _, clause := range clauses("must_not") {
+		matched, err := fakeMatches(clause, id, source)
+		if err != nil || matched {
+			return false, err
+		}
+	}
+
+	should := clauses("should")
+	minimum := 0
+	if len(should) > 0 && len(clauses("must")) == 0 && len(clauses("filter")) == 0 {
+		minimum = 1
+	}
+	if raw, ok := body["minimum_should_match"]; ok {
+		switch v := raw.(type) {
+		case float64:
+			minimum = int(v)
+		case string:
+			if parsed, err := strconv.Atoi(v); err == nil {
+				minimum = parsed
+			}
+		}
+	}
+
+	matched := 0
+	for _, clause := range should {
+		ok, err := fakeMatches(clause, id, source)
+		if err != nil {
+			return false, err
+		}
+		if ok {
+			matched++
+		}
+	}
+	return matched >= minimum, nil
+}
+
+// fieldValues resolves a dotted path, flattening arrays on the way. A
+// ".keyword" multi-field falls back to the field itself.
+func fieldValues(source map[string]interface{}, field string) []interface{} {
+	values := resolvePath([]interface{}{source}, strings.Split(field, "."))
+	if len(values) == 0 && strings.HasSuffix(field, ".keyword") {
+		values = resolvePath([]interface{}{source}, strings.Split(strings.TrimSuffix(field, ".keyword"), "."))
+	}
+	return values
+}
+
+func resolvePath(nodes []interface{}, parts []string) []interface{} {
+	if len(parts) == 0 {
+		var flat []interface{}
+		for _, node := range nodes {
+			if list, ok := node.([]interface{}); ok {
+				flat = append(flat, list...)
+			} else if node != nil {
+				flat = append(flat, node)
+			}
+		}
+		return flat
+	}
+
+	var next []interface{}
+	for _, node := range nodes {
+		switch v := node.(type) {
+		case map[string]interface{}:
+			if child, ok := v[parts[0]]; ok {
+				next = append(next, child)
+			}
+		case []interface{}:
+			next = append(next, resolvePath(v, parts[:1])...)
+		}
+	}
+	return resolvePath(next, parts[1:])
+}
+
+func firstValue(source map[string]interface{}, field string) (interface{}, bool) {
+	values := fieldValues(source, field)
+	if len(values) == 0 {
+		return nil, false
+	}
+	return values[0], true
+}
+
+func tokenize(text string) []string {
+	return strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsNumber(r)
+	})
+}
+
+func matchText(values []interface{}, text, operator string) bool {
+	wanted := tokenize(text)
+	if len(wanted) == 0 {
+		return false
+	}
+
+	present := make(map[string]bool)
+	for _, value := range values {
+		for _, token := range tokenize(fmt.Sprint(value)) {
+			present[token] = true
+		}
+	}
+
+	found := 0
+	for _, token := range wanted {
+		if present[token] {
+			found++
+		}
+	}
+	if operator == "and" {
+		return found == len(wanted)
+	}
+	return found > 0
+}
+
+func matchPhrase(values []interface{}, text string, prefix bool) bool {
+	phrase := tokenize(text)
+	if len(phrase) == 0 {
+		return false
+	}
+
+	for _, value := range values {
+		tokens := tokenize(fmt.Sprint(value))
+		for start := 0; start+len(phrase) <= len(tokens); start++ {
+			matched := true
+			for i, want := range phrase {
+				got := tokens[start+i]
+				last := i == len(phrase)-1
+				if got != want && !(prefix && last && strings.HasPrefix(got, want)) {
+					matched = false
+					break
+				}
+			}
+			if matched {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func containsValue(values []interface{}, want interface{}) bool {
+	for _, value := range values {
+		if compareValues(value, want) == 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// compareValues orders numbers numerically and everything else by its string form.
+func compareValues(a, b interface{}) int {
+	af, aNumber := toFloat(a)
+	bf, bNumber := toFloat(b)
+	if aNumber && bNumber {
+		switch {
+		case af < bf:
+			return -1
+		case af > bf:
+			return 1
+		}
+		return 0
+	}
+	return strings.Compare(fmt.Sprint(a), fmt.Sprint(b))
+}
+
+func toFloat(v interface{}) (float64, bool) {
+	switch n := v.(type) {
+	case float64:
+		return n, true
+	case int:
+		return float64(n), true
+	case int64:
+		return float64(n), true
+	case json.Number:
+		f, err := n.Float64()
+		return f, err == nil
+	}
+	return 0, false
+}
+
+func inRange(value interface{}, bounds map[string]interface{}) bool {
+	for op, bound := range bounds {
+		cmp := compareValues(value, bound)
+		switch op {
+		case "gt":
+			if cmp <= 0 {
+				return false
+			}
+		case "gte":
+			if cmp < 0 {
+				return false
+			}
+		case "lt":
+			if cmp >= 0 {
+				return false
+			}
+		case "lte":
+			if cmp > 0 {
+				return false
+			}
+		}
+	}
+	return true
+}
@@ -0,0 +1,267 @@
+package aristoteles
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/elastic/go-elasticsearch/v9/esapi"
+	"github.com/odysseia-greek/agora/aristoteles/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFakeIndices(t *testing.T) {
+	testClient, err := NewFakeClient(nil)
+	assert.Nil(t, err)
+	index := "dictionary"
+
+	exists, _, err := testClient.Index().IndexExists(index)
+	assert.Nil(t, err)
+	assert.False(t, exists)
+
+	created, err := testClient.Index().Create(index, NewBuilderImpl().GrammarIndex("grammar_policy"))
+	assert.Nil(t, err)
+	assert.True(t, created.Acknowledged)
+	assert.Equal(t, index, created.Index)
+
+	_, err = testClient.Index().Create(index, NewBuilderImpl().Index())
+	var elasticErr *ElasticError
+	assert.True(t, errors.As(err, &elasticErr))
+	assert.Equal(t, 400, elasticErr.StatusCode)
+
+	exists, info, err := testClient.Index().IndexExists(index)
+	assert.Nil(t, err)
+	assert.True(t, exists)
+	assert.Contains(t, info.Mappings, "properties")
+
+	deleted, err := testClient.Index().Delete(index)
+	assert.Nil(t, err)
+	assert.True(t, deleted)
+
+	_, err = testClient.Index().Delete(index)
+	assert.True(t, errors.As(err, &elasticErr))
+	assert.Equal(t, 404, elasticErr.StatusCode)
+}
+
+func TestFakeDocuments(t *testing.T) {
+	ctx := context.Background()
+	index := "dictionary"
+
+	t.Run("CreateGetUpdate", func(t *testing.T) {
+		testClient, err := NewFakeClient(nil)
+		assert.Nil(t, err)
+
+		created, err := testClient.Document().CreateWithId(index, "logos", []byte(`{"greek":"λόγος","english":"word","items":[]}`))
+		assert.Nil(t, err)
+		assert.Equal(t, "created", created.Result)
+		assert.Equal(t, int64(1), created.Version)
+
+		doc, err := testClient.Query().GetById(ctx, index, "logos")
+		assert.Nil(t, err)
+		assert.True(t, doc.Found)
+		assert.Equal(t, "word", doc.Source["english"])
+
+		updated, err := testClient.Document().Update(index, "logos", []byte(`{"english":"word, reason"}`))
+		assert.Nil(t, err)
+		assert.Equal(t, "updated", updated.Result)
+		assert.Equal(t, int64(2), updated.Version)
+		assert.Equal(t, int64(1), updated.SeqNo)
+
+		noop, err := testClient.Document().Update(index, "logos", []byte(`{"english":"word, reason"}`))
+		assert.Nil(t, err)
+		assert.Equal(t, "noop", noop.Result)
+		assert.Equal(t, int64(2), noop.Version)
+
+		added, err := testClient.Document().AddItemToDocument(index, "logos", `{"source":"John 1:1"}`, "items")
+		assert.Nil(t, err)
+		assert.Equal(t, "updated", added.Result)
+
+		doc, err = testClient.Query().GetById(ctx, index, "logos")
+		assert.Nil(t, err)
+		assert.Equal(t, "word, reason", doc.Source["english"])
+		assert.Equal(t, "λόγος", doc.Source["greek"])
+		assert.Len(t, doc.Source["items"], 1)
+	})
+
+	t.Run("Conflicts", func(t *testing.T) {
+		testClient, err := NewFakeClient(nil)
+		assert.Nil(t, err)
+
+		_, err = testClient.Document().CreateWithId(index, "logos", []byte(`{"greek":"λόγος"}`))
+		assert.Nil(t, err)
+		_, err = testClient.Document().CreateWithId(index, "logos", []byte(`{"greek":"λόγος"}`))
+		var elasticErr *ElasticError
+		assert.True(t, errors.As(err, &elasticErr))
+		assert.Equal(t, 409, elasticErr.StatusCode)
+	})
+
+	t.Run("Missing", func(t *testing.T) {
+		testClient, err := NewFakeClient(nil)
+		assert.Nil(t, err)
+
+		_, err = testClient.Document().Update(index, "missing", []byte(`{"english":"word"}`))
+		assert.NotNil(t, err)
+
+		_, err = testClient.Index().CreateDocument(index, []byte(`{"greek":"μάχη"}`))
+		assert.Nil(t, err)
+		doc, err := testClient.Query().GetById(ctx, index, "missing")
+		assert.NotNil(t, err)
+		assert.Nil(t, doc)
+	})
+
+	t.Run("SharedTransport", func(t *testing.T) {
+		fake := NewFakeTransport()
+		writer, err := NewFakeClient(fake)
+		assert.Nil(t, err)
+		reader, err := NewFakeClient(fake)
+		assert.Nil(t, err)
+
+		created, err := writer.Index().CreateDocument(index, []byte(`{"greek":"μάχη"}`))
+		assert.Nil(t, err)
+
+		doc, err := reader.Query().GetById(ctx, index, created.ID)
+		assert.Nil(t, err)
+		assert.Equal(t, "μάχη", doc.Source["greek"])
+	})
+
+	t.Run("UnsupportedEndpoint", func(t *testing.T) {
+		testClient, err := NewFakeClient(nil)
+		assert.Nil(t, err)
+
+		_, err = testClient.Access().ListUsers()
+		assert.NotNil(t, err)
+	})
+}
+
+func TestFakeSearch(t *testing.T) {
+	ctx := context.Background()
+	index := "text"
+	testClient, err := NewFakeClient(nil)
+	assert.Nil(t, err)
+
+	documents := []string{
+		`{"author":"herodotos","book":1,"greek":"Ἡροδότου Ἁλικαρνησσέος ἱστορίης ἀπόδεξις ἥδε"}`,
+		`{"author":"herodotos","book":2,"greek":"Ψαμμήτιχος δὲ ἐβασίλευσε"}`,
+		`{"author":"thucydides","book":1,"greek":"Θουκυδίδης Ἀθηναῖος ξυνέγραψε τὸν πόλεμον"}`,
+		`{"author":"plato","book":3,"greek":"κατέβην χθὲς εἰς Πειραιᾶ"}`,
+	}
+	var buf bytes.Buffer
+	for i, document := range documents {
+		fmt.Fprintf(&buf, "{\"index\":{\"_id\":\"%d\"}}\n%s\n", i+1, document)
+	}
+	bulk, err := testClient.Document().Bulk(buf, index)
+	assert.Nil(t, err)
+	assert.False(t, bulk.Errors)
+	assert.Len(t, bulk.Items, 4)
+
+	search := func(t *testing.T, request map[string]interface{}) []string {
+		t.Helper()
+		response, err := testClient.Query().Match(index, request)
+		assert.Nil(t, err)
+		var ids []string
+		for _, hit := range response.Hits.Hits {
+			ids = append(ids, hit.ID)
+		}
+		return ids
+	}
+
+	t.Run("Match", func(t *testing.T) {
+		assert.Equal(t, []string{"2"}, search(t, NewSearch().Query(NewMatchQuery("greek", "ψαμμήτιχος")).Build()))
+	})
+
+	t.Run("Term", func(t *testing.T) {
+		assert.Equal(t, []string{"1", "2"}, search(t, NewSearch().Query(NewTermQuery("author.keyword", "herodotos")).Build()))
+	})
+
+	t.Run("Bool", func(t *testing.T) {
+		query := NewBoolQuery().
+			Must(NewTermQuery("book", 1)).
+			MustNot(NewTermQuery("author", "thucydides"))
+		assert.Equal(t, []string{"1"}, search(t, NewSearch().Query(query).Build()))
+
+		should := NewBoolQuery().
+			Should(NewTermQuery("author", "plato"), NewTermQuery("author", "thucydides"))
+		assert.Equal(t, []string{"3", "4"}, search(t, NewSearch().Query(should).Build()))
+	})
+
+	t.Run("PhrasePrefix", func(t *testing.T) {
+		assert.Equal(t, []string{"3"}, search(t, NewSearch().Query(NewMatchPhrasePrefixQuery("greek", "τὸν πόλ")).Build()))
+	})
+
+	t.Run("SortAndPaging", func(t *testing.T) {
+		request := NewSearch().Query(NewMatchAllQuery()).Sort("book", "desc").From(1).Size(2).Build()
+		assert.Equal(t, []string{"2", "1"}, search(t, request))
+	})
+
+	t.Run("Count", func(t *testing.T) {
+		count, err := testClient.Query().CountRaw(ctx, index, NewSearch().Query(NewTermQuery("book", 1)).Build())
+		assert.Nil(t, err)
+		assert.Equal(t, int64(2), count.Count)
+	})
+
+	t.Run("UnknownQuery", func(t *testing.T) {
+		_, err := testClient.Query().Match(index, map[string]interface{}{"query": map[string]interface{}{"geo_shape": map[string]interface{}{}}})
+		assert.NotNil(t, err)
+	})
+
+	t.Run("MissingIndex", func(t *testing.T) {
+		_, err := testClient.Query().Match("missing", NewSearch().Build())
+		var elasticErr *ElasticError
+		assert.True(t, errors.As(err, &elasticErr))
+		assert.Equal(t, 404, elasticErr.StatusCode)
+	})
+}
+
+func TestFakeBulkAndScroll(t *testing.T) {
+	ctx := context.Background()
+	index := "grammar"
+	fake := NewFakeTransport()
+	testClient, err := NewFakeClient(fake)
+	assert.Nil(t, err)
+
+	indexer, err := testClient.Document().NewBulkIndexer(BulkIndexerConfig{Index: index, NumWorkers: 1, FlushCount: 7})
+	assert.Nil(t, err)
+	for i := 0; i < 25; i++ {
+		err := indexer.Add(ctx, BulkItem{
+			Action:     BulkIndex,
+			DocumentID: fmt.Sprintf("%02d", i),
+			Document:   map[string]interface{}{"declension": "first", "number": i},
+		})
+		assert.Nil(t, err)
+	}
+	assert.Nil(t, indexer.Add(ctx, BulkItem{Action: BulkDelete, DocumentID: "00"}))
+	assert.Nil(t, indexer.Close(ctx))
+	stats := indexer.Stats()
+	assert.Equal(t, uint64(25), stats.NumIndexed)
+	assert.Equal(t, uint64(1), stats.NumDeleted)
+	assert.Equal(t, uint64(0), stats.NumFailed)
+
+	response, err := testClient.Query().MatchWithScroll(index, NewSearch().Query(NewMatchQuery("declension", "first")).Build())
+	assert.Nil(t, err)
+	assert.Len(t, response.Hits.Hits, 24)
+	assert.Equal(t, "01", response.Hits.Hits[0].ID)
+	assert.Equal(t, "24", response.Hits.Hits[23].ID)
+
+	t.Run("PagesReportOriginalTotal", func(t *testing.T) {
+		esClient, err := CreateFakeClient(fake)
+		assert.Nil(t, err)
+
+		size := 10
+		jsonBody, err := perform(ctx, esClient, esapi.SearchRequest{Index: []string{index}, Size: &size, Scroll: time.Minute}, "search")
+		assert.Nil(t, err)
+		first, err := models.UnmarshalResponse(jsonBody)
+		assert.Nil(t, err)
+		assert.Len(t, first.Hits.Hits, 10)
+
+		jsonBody, err = perform(ctx, esClient, esapi.ScrollRequest{ScrollID: first.ScrollId}, "scroll")
+		assert.Nil(t, err)
+		next, err := models.UnmarshalResponse(jsonBody)
+		assert.Nil(t, err)
+		assert.Len(t, next.Hits.Hits, 10)
+		assert.Equal(t, first.Hits.Total.Value, next.Hits.Total.Value)
+		assert.Equal(t, int64(24), next.Hits.Total.Value)
+	})
+}
@@ -9,7 +9,8 @@ import (
 )
 
 type HealthImpl struct {
-	es *elasticsearch.Client
+	es      *elasticsearch.Client
+	breaker *CircuitBreaker
 }
 
 func NewHealthImpl(suppliedClient *elasticsearch.Client) (*HealthImpl, error) {
@@ -43,6 +44,12 @@ func (h *HealthImpl) Check(ticks, tick time.Duration) bool {
 }
 
 func (h *HealthImpl) Info() (elasticHealth models.DatabaseHealth) {
+	if h.breaker != nil {
+		defer func() {
+			elasticHealth.CircuitBreaker = h.breaker.State().String()
+		}()
+	}
+
 	res, err := h.es.Info()
 
 	if err != nil {
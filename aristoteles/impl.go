@@ -3,11 +3,15 @@ package aristoteles
 import (
 	"bytes"
 	"context"
+	"crypto/sha256"
+	"crypto/tls"
 	"crypto/x509"
+	"encoding/hex"
 	"errors"
 	"fmt"
 	"net/http"
 	"os"
+	"strings"
 	"time"
 
 	"github.com/elastic/go-elasticsearch/v9"
@@ -37,6 +41,7 @@ type Query interface {
 	MatchRawWithContext(ctx context.Context, index string, request map[string]interface{}) ([]byte, error)
 	CountRaw(ctx context.Context, index string, request map[string]interface{}) (*models.CountResponse, error)
 	GetById(ctx context.Context, index, id string) (*models.DirectResponse, error)
+	Iterate(ctx context.Context, index string, request map[string]interface{}, opts IteratorOptions) Iterator
 }
 
 type Document interface {
@@ -52,6 +57,14 @@ type Document interface {
 	CreateWithIdAndFirstItemWithContext(ctx context.Context, index, documentId, body, paramName string) (*models.CreateResult, error)
 	Bulk(buf bytes.Buffer, index string) (*BulkResponse, error)
 	BulkWithContext(ctx context.Context, buf bytes.Buffer, index string) (*BulkResponse, error)
+	NewBulkIndexer(config BulkIndexerConfig) (BulkIndexer, error)
+	GetWithVersion(ctx context.Context, index, id string) (*models.DirectResponse, error)
+	ReplaceIfMatch(ctx context.Context, index, id string, body []byte, version models.DocumentVersion) (*models.CreateResult, error)
+	UpdateWithOptions(ctx context.Context, index, id string, body []byte, opts UpdateOptions) (*models.CreateResult, error)
+	UpdateWithScript(ctx context.Context, index, id string, script models.Script, opts UpdateOptions) (*models.CreateResult, error)
+	Upsert(ctx context.Context, index, id string, body []byte, opts UpdateOptions) (*models.CreateResult, error)
+	UpdateByQuery(ctx context.Context, index string, request map[string]interface{}, script *models.Script, opts ByQueryOptions) (*models.ByQueryResponse, error)
+	DeleteByQuery(ctx context.Context, index string, request map[string]interface{}, opts ByQueryOptions) (*models.ByQueryResponse, error)
 }
 
 type Index interface {
@@ -65,6 +78,25 @@ type Index interface {
 	DeleteWithContext(ctx context.Context, index string) (bool, error)
 	IndexExists(index string) (bool, *models.IndexInfo, error)
 	IndexExistsWithContext(ctx context.Context, index string) (bool, *models.IndexInfo, error)
+	PutIndexTemplate(ctx context.Context, name string, template models.IndexTemplate) (bool, error)
+	GetIndexTemplate(ctx context.Context, name string) (*models.IndexTemplate, error)
+	DeleteIndexTemplate(ctx context.Context, name string) (bool, error)
+	PutComponentTemplate(ctx context.Context, name string, template models.ComponentTemplate) (bool, error)
+	GetComponentTemplate(ctx context.Context, name string) (*models.ComponentTemplate, error)
+	DeleteComponentTemplate(ctx context.Context, name string) (bool, error)
+	UpdateAliases(ctx context.Context, actions ...models.AliasAction) (bool, error)
+	AddAlias(ctx context.Context, index, alias string) (bool, error)
+	RemoveAlias(ctx context.Context, index, alias string) (bool, error)
+	SwapAlias(ctx context.Context, alias, from, to string) (bool, error)
+	GetAlias(ctx context.Context, alias string) ([]string, error)
+	Rollover(ctx context.Context, alias string, conditions models.RolloverConditions) (*models.RolloverResult, error)
+	Reindex(ctx context.Context, source []string, dest string, script *models.Script) (string, error)
+	WaitForTask(ctx context.Context, taskID string, pollInterval time.Duration) (*models.TaskStatus, error)
+	Migrate(ctx context.Context, opts MigrationOptions) (*MigrationResult, error)
+	GetMapping(ctx context.Context, index string) (map[string]interface{}, error)
+	GetSettings(ctx context.Context, index string) (map[string]interface{}, error)
+	PutMapping(ctx context.Context, index string, mapping map[string]interface{}) (bool, error)
+	Analyze(ctx context.Context, index, analyzer, text string) ([]models.AnalyzeToken, error)
 }
 
 type Policy interface {
@@ -72,6 +104,11 @@ type Policy interface {
 	CreatePolicyWithRollOverWithContext(ctx context.Context, name, maxAge, phase string) (*models.IndexCreateResult, error)
 	CreatePolicy(name, phase string) (*models.IndexCreateResult, error)
 	CreatePolicyWithContext(ctx context.Context, name, phase string) (*models.IndexCreateResult, error)
+	PutPolicy(ctx context.Context, name string, policy models.ILMPolicy) (*models.IndexCreateResult, error)
+	GetPolicy(ctx context.Context, name string) (*models.ILMPolicyInfo, error)
+	ListPolicies(ctx context.Context) (map[string]models.ILMPolicyInfo, error)
+	DeletePolicy(ctx context.Context, name string) (bool, error)
+	ExplainLifecycle(ctx context.Context, index string) (*models.ILMExplainResponse, error)
 }
 
 type Builder interface {
@@ -85,7 +122,9 @@ type Builder interface {
 	SearchAsYouTypeIndex(searchWord string) map[string]interface{}
 	Index() map[string]interface{}
 	TextIndex(policyName string) map[string]interface{}
+	TextIndexWithAnalyzer(policyName string, analyzer GreekAnalyzerOptions) map[string]interface{}
 	GrammarIndex(policyName string) map[string]interface{}
+	Search() *SearchRequest
 }
 
 type Health interface {
@@ -115,53 +154,20 @@ type Elastic struct {
 }
 
 func NewClient(config models.Config) (Client, error) {
-	var err error
-	var esClient *elasticsearch.Client
-	if config.ElasticCERT != "" {
-		esClient, err = createWithTLS(config)
-		if err != nil {
-			return nil, err
-		}
-	} else {
-		esClient, err = create(config)
-		if err != nil {
-			return nil, err
-		}
-	}
-
-	query, err := NewQueryImpl(esClient)
-	if err != nil {
-		return nil, err
-	}
-
-	index, err := NewIndexImpl(esClient)
-	if err != nil {
-		return nil, err
-	}
-
-	health, err := NewHealthImpl(esClient)
-	if err != nil {
-		return nil, err
-	}
-
-	access, err := NewAccessImpl(esClient)
-	if err != nil {
-		return nil, err
-	}
+	return NewClientWithOptions(config, ClientOptions{})
+}
 
-	document, err := NewDocumentImpl(esClient)
+func NewClientWithOptions(config models.Config, options ClientOptions) (Client, error) {
+	esClient, breaker, err := create(config, options)
 	if err != nil {
 		return nil, err
 	}
 
-	policy, err := NewPolicyImpl(esClient)
+	es, err := newElastic(esClient)
 	if err != nil {
 		return nil, err
 	}
-
-	builder := NewBuilderImpl()
-
-	es := &Elastic{query: query, index: index, builder: builder, health: health, access: access, document: document, policy: policy}
+	es.health.breaker = breaker
 
 	return es, nil
 }
@@ -197,6 +203,51 @@ func NewMockClient(fixtureFiles interface{}, statusCode int) (Client, error) {
 		return nil, err
 	}
 
+	es, err := newElastic(esClient)
+	if err != nil {
+		return nil, err
+	}
+
+	return es, nil
+}
+
+// NewFakeClient returns a client backed by a stateful in-memory FakeTransport.
+// Pass the same transport to several clients to share their data.
+func NewFakeClient(fake *FakeTransport) (Client, error) {
+	if fake == nil {
+		fake = NewFakeTransport()
+	}
+
+	esClient, err := CreateFakeClient(fake)
+	if err != nil {
+		return nil, err
+	}
+
+	es, err := newElastic(esClient)
+	if err != nil {
+		return nil, err
+	}
+
+	return es, nil
+}
+
+// NewExpectClient returns a client that answers from the expectations
+// registered on mock.
+func NewExpectClient(mock *ExpectTransport) (Client, error) {
+	esClient, err := CreateExpectClient(mock)
+	if err != nil {
+		return nil, err
+	}
+
+	es, err := newElastic(esClient)
+	if err != nil {
+		return nil, err
+	}
+
+	return es, nil
+}
+
+func newElastic(esClient *elasticsearch.Client) (*Elastic, error) {
 	query, err := NewQueryImpl(esClient)
 	if err != nil {
 		return nil, err
@@ -234,53 +285,103 @@ func NewMockClient(fixtureFiles interface{}, statusCode int) (Client, error) {
 	return es, nil
 }
 
-func create(config models.Config) (*elasticsearch.Client, error) {
+func create(config models.Config, options ClientOptions) (*elasticsearch.Client, *CircuitBreaker, error) {
+	transport, err := createTransport(config)
+	if err != nil {
+		return nil, nil, err
+	}
+
 	cfg := elasticsearch.Config{
-		Username:  config.Username,
-		Password:  config.Password,
-		Addresses: []string{config.Service},
+		Username:              config.Username,
+		Password:              config.Password,
+		APIKey:                config.APIKey,
+		ServiceToken:          config.ServiceToken,
+		Addresses:             config.Addresses(),
+		CompressRequestBody:   config.CompressRequestBody,
+		DiscoverNodesOnStart:  config.DiscoverNodesOnStart,
+		DiscoverNodesInterval: config.DiscoverNodesInterval,
+		Transport:             transport,
 	}
+	breaker := options.apply(&cfg)
 	es, err := elasticsearch.NewClient(cfg)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
-	return es, nil
+	return es, breaker, nil
 }
 
-func createWithTLS(config models.Config) (*elasticsearch.Client, error) {
-	caCert := []byte(config.ElasticCERT)
+// createTransport applies the TLS settings of config to a clone of its transport.
+// The fingerprint is checked by the dialer itself, so it survives the transport
+// being wrapped later on.
+func createTransport(config models.Config) (http.RoundTripper, error) {
+	if config.ElasticCERT == "" && config.ClientCERT == "" && config.CertificateFingerprint == "" {
+		return config.Transport, nil
+	}
 
-	// --> Clone the default HTTP transport
+	// --> Clone the supplied or the default HTTP transport
 
-	tp := http.DefaultTransport.(*http.Transport).Clone()
+	var tp *http.Transport
+	switch t := config.Transport.(type) {
+	case nil:
+		tp = http.DefaultTransport.(*http.Transport).Clone()
+	case *http.Transport:
+		tp = t.Clone()
+	default:
+		return nil, fmt.Errorf("tls settings require an *http.Transport, got %T", config.Transport)
+	}
+	if tp.TLSClientConfig == nil {
+		tp.TLSClientConfig = &tls.Config{}
+	}
 
 	// --> Initialize the set of root certificate authorities
 	//
-	var err error
+	if config.ElasticCERT != "" {
+		var err error
+		if tp.TLSClientConfig.RootCAs, err = x509.SystemCertPool(); err != nil {
+			return nil, fmt.Errorf("problem adding system CA: %w", err)
+		}
 
-	if tp.TLSClientConfig.RootCAs, err = x509.SystemCertPool(); err != nil {
-		return nil, fmt.Errorf("problem adding system CA: %w", err)
+		// --> Add the custom certificate authority
+		//
+		if ok := tp.TLSClientConfig.RootCAs.AppendCertsFromPEM([]byte(config.ElasticCERT)); !ok {
+			return nil, fmt.Errorf("problem adding CA from certificate data")
+		}
 	}
 
-	// --> Add the custom certificate authority
+	// --> Present a client certificate for mutual TLS
 	//
-	if ok := tp.TLSClientConfig.RootCAs.AppendCertsFromPEM(caCert); !ok {
-		return nil, fmt.Errorf("problem adding CA from certificate data")
+	if config.ClientCERT != "" {
+		certificate, err := tls.X509KeyPair([]byte(config.ClientCERT), []byte(config.ClientKEY))
+		if err != nil {
+			return nil, fmt.Errorf("problem loading client certificate: %w", err)
+		}
+		tp.TLSClientConfig.Certificates = []tls.Certificate{certificate}
 	}
 
-	cfg := elasticsearch.Config{
-		Username:  config.Username,
-		Password:  config.Password,
-		Addresses: []string{config.Service},
-		Transport: tp,
-	}
-	es, err := elasticsearch.NewClient(cfg)
-	if err != nil {
-		return nil, err
+	// --> Pin the certificate fingerprint, this replaces the CA verification
+	//
+	if config.CertificateFingerprint != "" {
+		fingerprint, err := hex.DecodeString(strings.ReplaceAll(config.CertificateFingerprint, ":", ""))
+		if err != nil {
+			return nil, fmt.Errorf("problem decoding certificate fingerprint: %w", err)
+		}
+
+		dialer := &tls.Dialer{Config: tp.TLSClientConfig.Clone()}
+		dialer.Config.InsecureSkipVerify = true
+		dialer.Config.VerifyConnection = func(state tls.ConnectionState) error {
+			for _, cert := range state.PeerCertificates {
+				digest := sha256.Sum256(cert.Raw)
+				if bytes.Equal(digest[:], fingerprint) {
+					return nil
+				}
+			}
+			return fmt.Errorf("certificate fingerprint mismatch, expected %s", config.CertificateFingerprint)
+		}
+		tp.DialTLSContext = dialer.DialContext
 	}
 
-	return es, nil
+	return tp, nil
 }
 
 func (e *Elastic) Query() Query {
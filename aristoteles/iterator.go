@@ -0,0 +1,241 @@
+package aristoteles
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/elastic/go-elasticsearch/v9"
+	"github.com/elastic/go-elasticsearch/v9/esapi"
+	"github.com/odysseia-greek/agora/aristoteles/models"
+)
+
+const (
+	defaultIteratorPageSize  = 100
+	defaultIteratorKeepAlive = time.Minute
+)
+
+type IteratorOptions struct {
+	// PageSize is the number of hits fetched per request, 100 when zero.
+	PageSize int
+	// KeepAlive is how long the point in time stays open between two pages, one
+	// minute when zero.
+	KeepAlive time.Duration
+}
+
+// Iterator yields the hits of a search one at a time and fetches the next page only
+// when the current one is used up. Always Close an iterator, also after an error, so
+// the point in time on the cluster is released.
+type Iterator interface {
+	Next() bool
+	Hit() models.Hit
+	Err() error
+	Close() error
+}
+
+type pitIterator struct {
+	ctx     context.Context
+	es      *elasticsearch.Client
+	index   string
+	request map[string]interface{}
+	options IteratorOptions
+
+	pitID       string
+	page        []models.Hit
+	position    int
+	current     models.Hit
+	searchAfter []interface{}
+	exhausted   bool
+	closed      bool
+	err         error
+}
+
+func (q *QueryImpl) Iterate(ctx context.Context, index string, request map[string]interface{}, opts IteratorOptions) Iterator {
+	if opts.PageSize <= 0 {
+		opts.PageSize = defaultIteratorPageSize
+	}
+	if opts.KeepAlive <= 0 {
+		opts.KeepAlive = defaultIteratorKeepAlive
+	}
+
+	return &pitIterator{
+		ctx:     ctx,
+		es:      q.es,
+		index:   index,
+		request: request,
+		options: opts,
+	}
+}
+
+func (it *pitIterator) Next() bool {
+	if it.err != nil || it.closed {
+		return false
+	}
+	if err := it.ctx.Err(); err != nil {
+		it.err = err
+		return false
+	}
+
+	if it.position >= len(it.page) {
+		if it.exhausted {
+			return false
+		}
+		if err := it.fetch(); err != nil {
+			it.err = err
+			return false
+		}
+		if len(it.page) == 0 {
+			return false
+		}
+	}
+
+	it.current = it.page[it.position]
+	it.position++
+	return true
+}
+
+func (it *pitIterator) Hit() models.Hit {
+	return it.current
+}
+
+func (it *pitIterator) Err() error {
+	return it.err
+}
+
+func (it *pitIterator) Close() error {
+	if it.closed {
+		return nil
+	}
+	it.closed = true
+	if it.pitID == "" {
+		return nil
+	}
+
+	// the point in time is released even when the iteration context is already done
+	ctx := context.WithoutCancel(it.ctx)
+	body, err := json.Marshal(map[string]string{"id": it.pitID})
+	if err != nil {
+		return err
+	}
+
+	res, err := esapi.ClosePointInTimeRequest{Body: bytes.NewReader(body)}.Do(ctx, it.es)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	if res.IsError() && res.StatusCode != 404 {
+		return newElasticError("close point in time", res)
+	}
+
+	return nil
+}
+
+func (it *pitIterator) open() error {
+	res, err := esapi.OpenPointInTimeRequest{
+		Index:     []string{it.index},
+		KeepAlive: it.options.KeepAlive,
+	}.Do(it.ctx, it.es)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	body, err := io.ReadAll(res.Body)
+	if err != nil {
+		return err
+	}
+	if res.IsError() {
+		return newElasticErrorFromBody("open point in time", res, body)
+	}
+
+	var pit struct {
+		ID string `json:"id"`
+	}
+	if err := json.Unmarshal(body, &pit); err != nil {
+		return err
+	}
+	if pit.ID == "" {
+		return fmt.Errorf("open point in time: response did not contain an id")
+	}
+
+	it.pitID = pit.ID
+	return nil
+}
+
+func (it *pitIterator) fetch() error {
+	if it.pitID == "" {
+		if err := it.open(); err != nil {
+			return err
+		}
+	}
+
+	query, err := toBuffer(it.pageRequest())
+	if err != nil {
+		return err
+	}
+
+	// searches against a point in time must not name an index
+	res, err := it.es.Search(
+		it.es.Search.WithContext(it.ctx),
+		it.es.Search.WithBody(&query),
+	)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	body, err := io.ReadAll(res.Body)
+	if err != nil {
+		return err
+	}
+	if res.IsError() {
+		return newElasticErrorFromBody("point in time search", res, body)
+	}
+
+	var page struct {
+		PitID string `json:"pit_id"`
+		models.Response
+	}
+	if err := json.Unmarshal(body, &page); err != nil {
+		return err
+	}
+
+	if page.PitID != "" {
+		it.pitID = page.PitID
+	}
+	it.page = page.Hits.Hits
+	it.position = 0
+	if len(it.page) < it.options.PageSize {
+		it.exhausted = true
+	}
+	if len(it.page) > 0 {
+		it.searchAfter = it.page[len(it.page)-1].Sort
+	}
+
+	return nil
+}
+
+func (it *pitIterator) pageRequest() map[string]interface{} {
+	request := make(map[string]interface{}, len(it.request)+4)
+	for key, value := range it.request {
+		request[key] = value
+	}
+
+	request["size"] = it.options.PageSize
+	request["pit"] = map[string]interface{}{
+		"id":         it.pitID,
+		"keep_alive": fmt.Sprintf("%dms", it.options.KeepAlive.Milliseconds()),
+	}
+	if _, ok := request["sort"]; !ok {
+		request["sort"] = []interface{}{map[string]interface{}{"_shard_doc": "asc"}}
+	}
+	if len(it.searchAfter) > 0 {
+		request["search_after"] = it.searchAfter
+	}
+
+	return request
+}
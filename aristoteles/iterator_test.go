@@ -0,0 +1,71 @@
+package aristoteles
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestQueryClientIterate(t *testing.T) {
+	index := "dictionary"
+	query := NewSearch().Query(NewMatchAllQuery()).Build()
+
+	t.Run("YieldsAllPages", func(t *testing.T) {
+		testClient, err := NewMockClient([]string{"openPit", "pitPage1", "pitPage2", "closePit"}, 200)
+		assert.Nil(t, err)
+
+		it := testClient.Query().Iterate(context.Background(), index, query, IteratorOptions{PageSize: 2})
+		var words []string
+		for it.Next() {
+			words = append(words, it.Hit().Source["greek"].(string))
+		}
+		assert.Nil(t, it.Err())
+		assert.Nil(t, it.Close())
+		assert.Equal(t, []string{"ἀγαθός", "ἄγγελος", "ἄγω"}, words)
+	})
+
+	t.Run("PageRequestCarriesPitAndSearchAfter", func(t *testing.T) {
+		it := &pitIterator{
+			request:     query,
+			options:     IteratorOptions{PageSize: 50, KeepAlive: defaultIteratorKeepAlive},
+			pitID:       "pit-id",
+			searchAfter: []interface{}{float64(1)},
+		}
+
+		sut := it.pageRequest()
+		assert.Equal(t, 50, sut["size"])
+		assert.Equal(t, map[string]interface{}{"id": "pit-id", "keep_alive": "60000ms"}, sut["pit"])
+		assert.Equal(t, []interface{}{float64(1)}, sut["search_after"])
+		assert.NotNil(t, sut["sort"])
+		_, mutated := query["pit"]
+		assert.False(t, mutated)
+	})
+
+	t.Run("RespectsCancelledContext", func(t *testing.T) {
+		testClient, err := NewMockClient([]string{"openPit", "pitPage1"}, 200)
+		assert.Nil(t, err)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		it := testClient.Query().Iterate(ctx, index, query, IteratorOptions{})
+		assert.False(t, it.Next())
+		assert.True(t, errors.Is(it.Err(), context.Canceled))
+		assert.Nil(t, it.Close())
+	})
+
+	t.Run("OpenFails", func(t *testing.T) {
+		testClient, err := NewMockClient("serviceDown", 502)
+		assert.Nil(t, err)
+
+		it := testClient.Query().Iterate(context.Background(), index, query, IteratorOptions{})
+		assert.False(t, it.Next())
+
+		var elasticErr *ElasticError
+		assert.True(t, errors.As(it.Err(), &elasticErr))
+		assert.Equal(t, "open point in time", elasticErr.Operation)
+		assert.Nil(t, it.Close())
+	})
+}
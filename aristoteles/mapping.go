@@ -0,0 +1,170 @@
+package aristoteles
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+
+	"github.com/elastic/go-elasticsearch/v9/esapi"
+)
+
+type MappingDiff struct {
+	// Added holds fields present in actual but not in expected.
+	Added []string
+	// Removed holds fields present in expected but missing from actual.
+	Removed []string
+	Changed []FieldChange
+}
+
+type FieldChange struct {
+	Field    string
+	Expected map[string]interface{}
+	Actual   map[string]interface{}
+}
+
+func (d MappingDiff) Empty() bool {
+	return len(d.Added) == 0 && len(d.Removed) == 0 && len(d.Changed) == 0
+}
+
+func (i *IndexImpl) GetMapping(ctx context.Context, index string) (map[string]interface{}, error) {
+	jsonBody, err := perform(ctx, i.es, esapi.IndicesGetMappingRequest{Index: []string{index}}, "get mapping")
+	if err != nil {
+		return nil, err
+	}
+
+	return singleIndexSection(jsonBody, index, "mappings")
+}
+
+func (i *IndexImpl) GetSettings(ctx context.Context, index string) (map[string]interface{}, error) {
+	jsonBody, err := perform(ctx, i.es, esapi.IndicesGetSettingsRequest{Index: []string{index}}, "get settings")
+	if err != nil {
+		return nil, err
+	}
+
+	return singleIndexSection(jsonBody, index, "settings")
+}
+
+func (i *IndexImpl) PutMapping(ctx context.Context, index string, mapping map[string]interface{}) (bool, error) {
+	body, err := toJSONReader(mapping)
+	if err != nil {
+		return false, err
+	}
+
+	jsonBody, err := perform(ctx, i.es, esapi.IndicesPutMappingRequest{Index: []string{index}, Body: body}, "put mapping")
+	if err != nil {
+		return false, err
+	}
+
+	return parseAcknowledged(jsonBody)
+}
+
+// singleIndexSection extracts one section of a per-index response. When index
+// is an alias the response is keyed by the concrete index, so a single entry
+// is accepted under any name.
+func singleIndexSection(jsonBody []byte, index, section string) (map[string]interface{}, error) {
+	var indices map[string]map[string]map[string]interface{}
+	if err := json.Unmarshal(jsonBody, &indices); err != nil {
+		return nil, err
+	}
+
+	if data, ok := indices[index]; ok {
+		return data[section], nil
+	}
+	if len(indices) == 1 {
+		for _, data := range indices {
+			return data[section], nil
+		}
+	}
+
+	return nil, fmt.Errorf("%s for %q: expected a single index in response, got %d", section, index, len(indices))
+}
+
+// DiffMapping compares two mappings field by field. Both arguments may either
+// be a mapping ({"properties": ...}) or a full create index body as returned by
+// the Builder ({"settings": ..., "mappings": ...}). Multi-fields are reported as
+// "field.sub" and nested objects by their dotted path.
+func DiffMapping(expected, actual map[string]interface{}) (MappingDiff, error) {
+	expectedFields, err := flattenMapping(expected)
+	if err != nil {
+		return MappingDiff{}, fmt.Errorf("expected mapping: %w", err)
+	}
+	actualFields, err := flattenMapping(actual)
+	if err != nil {
+		return MappingDiff{}, fmt.Errorf("actual mapping: %w", err)
+	}
+
+	var diff MappingDiff
+	for field, expectedDefinition := range expectedFields {
+		actualDefinition, ok := actualFields[field]
+		if !ok {
+			diff.Removed = append(diff.Removed, field)
+			continue
+		}
+		if !reflect.DeepEqual(expectedDefinition, actualDefinition) {
+			diff.Changed = append(diff.Changed, FieldChange{Field: field, Expected: expectedDefinition, Actual: actualDefinition})
+		}
+	}
+	for field := range actualFields {
+		if _, ok := expectedFields[field]; !ok {
+			diff.Added = append(diff.Added, field)
+		}
+	}
+
+	sort.Strings(diff.Added)
+	sort.Strings(diff.Removed)
+	sort.Slice(diff.Changed, func(a, b int) bool { return diff.Changed[a].Field < diff.Changed[b].Field })
+
+	return diff, nil
+}
+
+func flattenMapping(mapping map[string]interface{}) (map[string]map[string]interface{}, error) {
+	// a json round trip makes builder output (ints, []string) comparable with
+	// decoded responses (float64, []interface{})
+	raw, err := json.Marshal(mapping)
+	if err != nil {
+		return nil, err
+	}
+	var normalized map[string]interface{}
+	if err := json.Unmarshal(raw, &normalized); err != nil {
+		return nil, err
+	}
+
+	if mappings, ok := normalized["mappings"].(map[string]interface{}); ok {
+		normalized = mappings
+	}
+
+	fields := make(map[string]map[string]interface{})
+	flattenProperties("", normalized, fields)
+	return fields, nil
+}
+
+func flattenProperties(prefix string, node map[string]interface{}, fields map[string]map[string]interface{}) {
+	for _, key := range []string{"properties", "fields"} {
+		children, ok := node[key].(map[string]interface{})
+		if !ok {
+			continue
+		}
+		for name, rawChild := range children {
+			child, ok := rawChild.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			path := name
+			if prefix != "" {
+				path = prefix + "." + name
+			}
+
+			definition := make(map[string]interface{}, len(child))
+			for attribute, value := range child {
+				if attribute == "properties" || attribute == "fields" {
+					continue
+				}
+				definition[attribute] = value
+			}
+			fields[path] = definition
+			flattenProperties(path, child, fields)
+		}
+	}
+}
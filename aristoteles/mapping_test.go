@@ -0,0 +1,123 @@
+package aristoteles
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMappingClient(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("GetMappingThroughAlias", func(t *testing.T) {
+		testClient, err := NewMockClient("getMapping", 200)
+		assert.Nil(t, err)
+
+		mapping, err := testClient.Index().GetMapping(ctx, "text")
+		assert.Nil(t, err)
+		assert.Contains(t, mapping, "properties")
+	})
+
+	t.Run("GetMappingMultipleIndices", func(t *testing.T) {
+		testClient, err := NewMockClient("getAlias", 200)
+		assert.Nil(t, err)
+
+		mapping, err := testClient.Index().GetMapping(ctx, "dictionary")
+		assert.NotNil(t, err)
+		assert.Nil(t, mapping)
+	})
+
+	t.Run("GetSettings", func(t *testing.T) {
+		testClient, err := NewMockClient("getSettings", 200)
+		assert.Nil(t, err)
+
+		settings, err := testClient.Index().GetSettings(ctx, "text-2024.03.01")
+		assert.Nil(t, err)
+		index := settings["index"].(map[string]interface{})
+		assert.Equal(t, "1", index["number_of_shards"])
+	})
+
+	t.Run("GetSettingsNotFound", func(t *testing.T) {
+		testClient, err := NewMockClient("deleteIndex404", 404)
+		assert.Nil(t, err)
+
+		settings, err := testClient.Index().GetSettings(ctx, "missing")
+		assert.NotNil(t, err)
+		assert.Nil(t, settings)
+	})
+
+	t.Run("PutMapping", func(t *testing.T) {
+		testClient, err := NewMockClient("deleteIndex", 200)
+		assert.Nil(t, err)
+
+		acknowledged, err := testClient.Index().PutMapping(ctx, "text", map[string]interface{}{
+			"properties": map[string]interface{}{"edition": map[string]interface{}{"type": "keyword"}},
+		})
+		assert.Nil(t, err)
+		assert.True(t, acknowledged)
+	})
+}
+
+func TestDiffMapping(t *testing.T) {
+	builder := NewBuilderImpl()
+
+	t.Run("DetectsDrift", func(t *testing.T) {
+		testClient, err := NewMockClient("getMapping", 200)
+		assert.Nil(t, err)
+		actual, err := testClient.Index().GetMapping(context.Background(), "text")
+		assert.Nil(t, err)
+
+		diff, err := DiffMapping(builder.TextIndex("text_policy"), actual)
+		assert.Nil(t, err)
+		assert.False(t, diff.Empty())
+		assert.Equal(t, []string{"legacyId"}, diff.Added)
+		assert.Empty(t, diff.Removed)
+		assert.Len(t, diff.Changed, 1)
+		assert.Equal(t, "greek", diff.Changed[0].Field)
+		assert.Equal(t, "greek_analyzer", diff.Changed[0].Expected["analyzer"])
+		assert.NotContains(t, diff.Changed[0].Actual, "analyzer")
+	})
+
+	t.Run("IdenticalBuilderOutput", func(t *testing.T) {
+		expected := builder.GrammarIndex("grammar_policy")
+		diff, err := DiffMapping(expected, expected["mappings"].(map[string]interface{}))
+		assert.Nil(t, err)
+		assert.True(t, diff.Empty())
+	})
+
+	t.Run("MultiFieldsAndObjects", func(t *testing.T) {
+		expected := map[string]interface{}{
+			"properties": map[string]interface{}{
+				"ruleName": map[string]interface{}{
+					"type":   "text",
+					"fields": map[string]interface{}{"keyword": map[string]interface{}{"type": "keyword"}},
+				},
+				"meta": map[string]interface{}{
+					"properties": map[string]interface{}{
+						"created": map[string]interface{}{"type": "date"},
+					},
+				},
+			},
+		}
+		actual := map[string]interface{}{
+			"properties": map[string]interface{}{
+				"ruleName": map[string]interface{}{
+					"type": "text",
+				},
+				"meta": map[string]interface{}{
+					"properties": map[string]interface{}{
+						"created": map[string]interface{}{"type": "date", "format": "epoch_millis"},
+					},
+				},
+			},
+		}
+
+		diff, err := DiffMapping(expected, actual)
+		assert.Nil(t, err)
+		assert.Empty(t, diff.Added)
+		assert.Equal(t, []string{"ruleName.keyword"}, diff.Removed)
+		assert.Len(t, diff.Changed, 1)
+		assert.Equal(t, "meta.created", diff.Changed[0].Field)
+	})
+}
@@ -0,0 +1,209 @@
+package aristoteles
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/elastic/go-elasticsearch/v9/esapi"
+	"github.com/odysseia-greek/agora/aristoteles/models"
+)
+
+var ErrMigrationVerification = errors.New("migration verification failed")
+
+type MigrationOptions struct {
+	// Alias is read from before and points at NewIndex once the migration succeeds.
+	// It may also name a concrete index, as it does before the first migration;
+	// that index is then removed in the same _aliases call that adds the alias.
+	Alias string
+	// NewIndex defaults to "<alias>-v<Version>", so one of them must be set.
+	NewIndex string
+	Version  int
+	// Request is the create index body, for example the result of Builder().GrammarIndex.
+	Request      map[string]interface{}
+	Script       *models.Script
+	PollInterval time.Duration
+	// DeleteOld removes the indices that previously held the alias.
+	DeleteOld bool
+}
+
+type MigrationResult struct {
+	OldIndices []string
+	// FromIndex is set when Alias was a concrete index rather than an alias.
+	FromIndex   bool
+	NewIndex    string
+	SourceCount int64
+	TargetCount int64
+	Reindex     *models.ByQueryResponse
+}
+
+// Reindex starts a _reindex task from source into dest and returns its task id.
+func (i *IndexImpl) Reindex(ctx context.Context, source []string, dest string, script *models.Script) (string, error) {
+	request := map[string]interface{}{
+		"source": map[string]interface{}{"index": source},
+		"dest":   map[string]interface{}{"index": dest},
+	}
+	if script != nil {
+		request["script"] = script
+	}
+
+	body, err := toJSONReader(request)
+	if err != nil {
+		return "", err
+	}
+
+	waitForCompletion := false
+	jsonBody, err := perform(ctx, i.es, esapi.ReindexRequest{Body: body, WaitForCompletion: &waitForCompletion}, "reindex")
+	if err != nil {
+		return "", err
+	}
+
+	task, err := models.UnmarshalTaskSubmitResponse(jsonBody)
+	if err != nil {
+		return "", err
+	}
+	if task.Task == "" {
+		return "", fmt.Errorf("reindex: no task id in response: %s", jsonBody)
+	}
+
+	return task.Task, nil
+}
+
+// WaitForTask polls the tasks API until the task completes or ctx is done.
+func (i *IndexImpl) WaitForTask(ctx context.Context, taskID string, pollInterval time.Duration) (*models.TaskStatus, error) {
+	return waitForTask(ctx, i.es, taskID, pollInterval)
+}
+
+func (i *IndexImpl) Migrate(ctx context.Context, opts MigrationOptions) (*MigrationResult, error) {
+	if opts.Alias == "" {
+		return nil, errors.New("migration: alias is required")
+	}
+
+	newIndex := opts.NewIndex
+	if newIndex == "" {
+		if opts.Version <= 0 {
+			return nil, errors.New("migration: new index or a positive version is required")
+		}
+		newIndex = fmt.Sprintf("%s-v%d", opts.Alias, opts.Version)
+	}
+
+	oldIndices, fromIndex, err := i.resolveMigrationSource(ctx, opts.Alias)
+	if err != nil {
+		return nil, err
+	}
+	for _, old := range oldIndices {
+		if old == newIndex {
+			return nil, fmt.Errorf("migration: alias %q already points at %q", opts.Alias, newIndex)
+		}
+	}
+
+	result := &MigrationResult{OldIndices: oldIndices, FromIndex: fromIndex, NewIndex: newIndex}
+
+	if _, err := i.CreateWithContext(ctx, newIndex, opts.Request); err != nil {
+		return nil, fmt.Errorf("migration: create %q: %w", newIndex, err)
+	}
+
+	if err := i.migrate(ctx, opts, result); err != nil {
+		if _, rollbackErr := i.DeleteWithContext(context.WithoutCancel(ctx), newIndex); rollbackErr != nil {
+			return result, errors.Join(err, fmt.Errorf("migration: rollback %q: %w", newIndex, rollbackErr))
+		}
+		return result, err
+	}
+
+	// a concrete source index is already gone, the alias took its name
+	if opts.DeleteOld && !fromIndex {
+		for _, old := range oldIndices {
+			if _, err := i.DeleteWithContext(ctx, old); err != nil {
+				return result, fmt.Errorf("migration: delete old index %q: %w", old, err)
+			}
+		}
+	}
+
+	return result, nil
+}
+
+func (i *IndexImpl) migrate(ctx context.Context, opts MigrationOptions, result *MigrationResult) error {
+	taskID, err := i.Reindex(ctx, result.OldIndices, result.NewIndex, opts.Script)
+	if err != nil {
+		return fmt.Errorf("migration: %w", err)
+	}
+
+	status, err := i.WaitForTask(ctx, taskID, opts.PollInterval)
+	if err != nil {
+		return fmt.Errorf("migration: %w", err)
+	}
+	result.Reindex = status.Response
+	if status.Response != nil && len(status.Response.Failures) > 0 {
+		return fmt.Errorf("%w: reindex reported %d failures", ErrMigrationVerification, len(status.Response.Failures))
+	}
+
+	if _, err := perform(ctx, i.es, esapi.IndicesRefreshRequest{Index: []string{result.NewIndex}}, "refresh index"); err != nil {
+		return fmt.Errorf("migration: %w", err)
+	}
+
+	result.SourceCount, err = i.count(ctx, opts.Alias)
+	if err != nil {
+		return fmt.Errorf("migration: %w", err)
+	}
+	result.TargetCount, err = i.count(ctx, result.NewIndex)
+	if err != nil {
+		return fmt.Errorf("migration: %w", err)
+	}
+	if result.SourceCount != result.TargetCount {
+		return fmt.Errorf("%w: %s has %d documents, %s has %d", ErrMigrationVerification, opts.Alias, result.SourceCount, result.NewIndex, result.TargetCount)
+	}
+
+	actions := make([]models.AliasAction, 0, len(result.OldIndices)+1)
+	actions = append(actions, models.AddAliasAction(result.NewIndex, opts.Alias))
+	for _, old := range result.OldIndices {
+		if result.FromIndex {
+			// an alias cannot share its name with an index, so the index goes in the same call
+			actions = append(actions, models.RemoveIndexAction(old))
+		} else {
+			actions = append(actions, models.RemoveAliasAction(old, opts.Alias))
+		}
+	}
+	if _, err := i.UpdateAliases(ctx, actions...); err != nil {
+		return fmt.Errorf("migration: swap alias: %w", err)
+	}
+
+	return nil
+}
+
+// resolveMigrationSource returns the indices behind alias, or alias itself when
+// it is a concrete index.
+func (i *IndexImpl) resolveMigrationSource(ctx context.Context, alias string) ([]string, bool, error) {
+	oldIndices, err := i.GetAlias(ctx, alias)
+	if err == nil {
+		return oldIndices, false, nil
+	}
+	if !IsNotFound(err) {
+		return nil, false, fmt.Errorf("migration: resolve alias %q: %w", alias, err)
+	}
+
+	exists, _, existsErr := i.IndexExistsWithContext(ctx, alias)
+	if existsErr != nil {
+		return nil, false, fmt.Errorf("migration: resolve index %q: %w", alias, existsErr)
+	}
+	if !exists {
+		return nil, false, fmt.Errorf("migration: resolve alias %q: %w", alias, err)
+	}
+
+	return []string{alias}, true, nil
+}
+
+func (i *IndexImpl) count(ctx context.Context, index string) (int64, error) {
+	jsonBody, err := perform(ctx, i.es, esapi.CountRequest{Index: []string{index}}, "count")
+	if err != nil {
+		return 0, err
+	}
+
+	var count models.CountResponse
+	if err := json.Unmarshal(jsonBody, &count); err != nil {
+		return 0, err
+	}
+
+	return count.Count, nil
+}
@@ -0,0 +1,169 @@
+package aristoteles
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/odysseia-greek/agora/aristoteles/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMigrate(t *testing.T) {
+	ctx := context.Background()
+	opts := MigrationOptions{
+		Alias:        "dictionary",
+		Version:      2,
+		Request:      map[string]interface{}{"settings": map[string]interface{}{"number_of_shards": 1}},
+		Script:       &models.Script{Source: "ctx._source.version = params.version", Params: map[string]interface{}{"version": 2}},
+		PollInterval: time.Millisecond,
+	}
+
+	t.Run("Success", func(t *testing.T) {
+		files := []string{"migrationAlias", "createIndex", "reindexTask", "taskRunning", "taskCompleted", "refresh", "count", "count", "deleteIndex"}
+		testClient, err := NewMockClient(files, 200)
+		assert.Nil(t, err)
+
+		result, err := testClient.Index().Migrate(ctx, opts)
+		assert.Nil(t, err)
+		assert.Equal(t, "dictionary-v2", result.NewIndex)
+		assert.Equal(t, []string{"dictionary-2024.03.01"}, result.OldIndices)
+		assert.Equal(t, int64(1132), result.SourceCount)
+		assert.Equal(t, int64(1132), result.TargetCount)
+		assert.Equal(t, int64(1132), result.Reindex.Created)
+	})
+
+	t.Run("FromConcreteIndex", func(t *testing.T) {
+		mock := NewExpectTransport()
+		mock.Expect(http.MethodGet, "/_alias/dictionary").RespondWith(404, []byte(`{"error":"alias [dictionary] missing","status":404}`))
+		mock.Expect(http.MethodGet, "/dictionary").RespondWith(200, []byte(`{"dictionary":{"aliases":{},"settings":{}}}`))
+		mock.Expect(http.MethodPut, "/dictionary-v2").RespondWithFixture(200, "createIndex")
+		mock.Expect(http.MethodPost, "/_reindex").
+			WithBody(BodyContains(`"index":["dictionary"]`)).
+			RespondWithFixture(200, "reindexTask")
+		mock.Expect(http.MethodGet, "/_tasks/*").RespondWithFixture(200, "taskCompleted")
+		mock.Expect(http.MethodPost, "/dictionary-v2/_refresh").RespondWithFixture(200, "refresh")
+		mock.Expect(http.MethodPost, "/dictionary/_count").RespondWithFixture(200, "count")
+		mock.Expect(http.MethodPost, "/dictionary-v2/_count").RespondWithFixture(200, "count")
+		mock.Expect(http.MethodPost, "/_aliases").
+			WithBody(BodyJSONEq(`{"actions":[{"add":{"index":"dictionary-v2","alias":"dictionary"}},{"remove_index":{"index":"dictionary"}}]}`)).
+			RespondWith(200, []byte(`{"acknowledged":true}`))
+		testClient, err := NewExpectClient(mock)
+		assert.Nil(t, err)
+
+		withDelete := opts
+		withDelete.DeleteOld = true
+		result, err := testClient.Index().Migrate(ctx, withDelete)
+		assert.Nil(t, err)
+		assert.True(t, result.FromIndex)
+		assert.Equal(t, []string{"dictionary"}, result.OldIndices)
+		assert.Equal(t, "dictionary-v2", result.NewIndex)
+		mock.AssertExpectations(t)
+	})
+
+	t.Run("MissingSource", func(t *testing.T) {
+		mock := NewExpectTransport()
+		mock.Expect(http.MethodGet, "/_alias/dictionary").RespondWith(404, []byte(`{"error":"alias [dictionary] missing","status":404}`))
+		mock.Expect(http.MethodGet, "/dictionary").RespondWithFixture(404, "deleteIndex404")
+		testClient, err := NewExpectClient(mock)
+		assert.Nil(t, err)
+
+		result, err := testClient.Index().Migrate(ctx, opts)
+		assert.True(t, IsNotFound(err))
+		assert.Nil(t, result)
+		mock.AssertExpectations(t)
+	})
+
+	t.Run("DeleteOld", func(t *testing.T) {
+		files := []string{"migrationAlias", "createIndex", "reindexTask", "taskCompleted", "refresh", "count", "count", "deleteIndex", "deleteIndex"}
+		testClient, err := NewMockClient(files, 200)
+		assert.Nil(t, err)
+
+		withDelete := opts
+		withDelete.DeleteOld = true
+		result, err := testClient.Index().Migrate(ctx, withDelete)
+		assert.Nil(t, err)
+		assert.Equal(t, "dictionary-v2", result.NewIndex)
+	})
+
+	t.Run("CountMismatchRollsBack", func(t *testing.T) {
+		files := []string{"migrationAlias", "createIndex", "reindexTask", "taskCompleted", "refresh", "count", "countMismatch", "deleteIndex"}
+		testClient, err := NewMockClient(files, 200)
+		assert.Nil(t, err)
+
+		result, err := testClient.Index().Migrate(ctx, opts)
+		assert.True(t, errors.Is(err, ErrMigrationVerification))
+		assert.Equal(t, int64(1132), result.SourceCount)
+		assert.Equal(t, int64(1000), result.TargetCount)
+	})
+
+	t.Run("ReindexFailuresRollBack", func(t *testing.T) {
+		files := []string{"migrationAlias", "createIndex", "reindexTask", "taskFailures", "deleteIndex"}
+		testClient, err := NewMockClient(files, 200)
+		assert.Nil(t, err)
+
+		_, err = testClient.Index().Migrate(ctx, opts)
+		assert.True(t, errors.Is(err, ErrMigrationVerification))
+	})
+
+	t.Run("RollbackFails", func(t *testing.T) {
+		esClient, err := CreateMockClientWithStatusCodes(
+			[]string{"migrationAlias", "createIndex", "reindexTask", "taskFailures", "deleteIndex404"},
+			[]int{200, 200, 200, 200, 404},
+		)
+		assert.Nil(t, err)
+		index, err := NewIndexImpl(esClient)
+		assert.Nil(t, err)
+
+		_, err = index.Migrate(ctx, opts)
+		assert.True(t, errors.Is(err, ErrMigrationVerification))
+		assert.Contains(t, err.Error(), "rollback")
+	})
+
+	t.Run("AliasAlreadyMigrated", func(t *testing.T) {
+		testClient, err := NewMockClient("migrationAlias", 200)
+		assert.Nil(t, err)
+
+		migrated := opts
+		migrated.NewIndex = "dictionary-2024.03.01"
+		result, err := testClient.Index().Migrate(ctx, migrated)
+		assert.NotNil(t, err)
+		assert.Nil(t, result)
+	})
+
+	t.Run("MissingAlias", func(t *testing.T) {
+		testClient, err := NewMockClient("createIndex", 200)
+		assert.Nil(t, err)
+
+		result, err := testClient.Index().Migrate(ctx, MigrationOptions{})
+		assert.NotNil(t, err)
+		assert.Nil(t, result)
+	})
+
+	t.Run("MissingVersion", func(t *testing.T) {
+		testClient, err := NewMockClient("migrationAlias", 200)
+		assert.Nil(t, err)
+
+		result, err := testClient.Index().Migrate(ctx, MigrationOptions{Alias: "dictionary"})
+		assert.NotNil(t, err)
+		assert.Contains(t, err.Error(), "version")
+		assert.Nil(t, result)
+	})
+
+	t.Run("WaitForTaskCancelled", func(t *testing.T) {
+		files := make([]string, 500)
+		for i := range files {
+			files[i] = "taskRunning"
+		}
+		testClient, err := NewMockClient(files, 200)
+		assert.Nil(t, err)
+
+		cancelled, cancel := context.WithTimeout(ctx, 20*time.Millisecond)
+		defer cancel()
+		status, err := testClient.Index().WaitForTask(cancelled, "oTUltX4IQMOUUVeiohTt8A:12345", time.Millisecond)
+		assert.True(t, errors.Is(err, context.DeadlineExceeded))
+		assert.Nil(t, status)
+	})
+}
@@ -2,6 +2,7 @@ package aristoteles
 
 import (
 	"bytes"
+	"context"
 	"fmt"
 	"github.com/elastic/go-elasticsearch/v9"
 	"io"
@@ -63,7 +64,22 @@ type MockTransport struct {
 	RoundTripFn func(req *http.Request) (*http.Response, error)
 }
 
+// mockRoundTripKey marks a request that is being answered by RoundTripFn.
+type mockRoundTripKey struct{}
+
+// RoundTrip replays Responses in order. When RoundTripFn is set it answers the
+// request instead, so a test can inspect the request or pick a response. A
+// RoundTripFn that calls back into RoundTrip with the same request gets the
+// next of Responses rather than recursing. The clear scroll request that
+// MatchWithScroll sends when it is done is answered without using a fixture.
 func (t *MockTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if t.RoundTripFn != nil && req.Context().Value(mockRoundTripKey{}) != t {
+		return t.RoundTripFn(req.WithContext(context.WithValue(req.Context(), mockRoundTripKey{}, t)))
+	}
+	if req.Method == http.MethodDelete && strings.HasPrefix(req.URL.Path, "/_search/scroll") {
+		return expectResponse(req, http.StatusOK, []byte(`{"succeeded":true,"num_freed":1}`)), nil
+	}
+
 	response := t.Responses[t.ResponseIdx]
 	t.ResponseIdx = (t.ResponseIdx + 1) % len(t.Responses)
 	return response, nil
@@ -101,7 +117,6 @@ func CreateMockClientWithStatusCodes(fixtureFiles []string, statusCodes []int) (
 		Responses:   responses,
 		ResponseIdx: 0,
 	}
-	mockTrans.RoundTripFn = func(req *http.Request) (*http.Response, error) { return mockTrans.RoundTrip(req) }
 
 	client, err := elasticsearch.NewClient(elasticsearch.Config{
 		Transport: &mockTrans,
@@ -123,10 +138,16 @@ func toHTTPStatusCode(statusCode int) int {
 		return http.StatusForbidden
 	case 404:
 		return http.StatusNotFound
+	case 409:
+		return http.StatusConflict
+	case 429:
+		return http.StatusTooManyRequests
 	case 500:
 		return http.StatusInternalServerError
 	case 502:
 		return http.StatusBadGateway
+	case 503:
+		return http.StatusServiceUnavailable
 	default:
 		return http.StatusOK
 	}
@@ -0,0 +1,294 @@
+package aristoteles
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"path"
+	"reflect"
+	"strings"
+	"sync"
+
+	"github.com/elastic/go-elasticsearch/v9"
+)
+
+// ExpectTransport answers requests from registered expectations instead of
+// replaying fixtures in order. Each expectation matches on method, a path
+// pattern and optionally the request body; every request is captured so a
+// test can inspect what was sent. A request that matches no expectation gets
+// a 500 response and is reported by ExpectationsMet.
+type ExpectTransport struct {
+	mu           sync.Mutex
+	expectations []*Expectation
+	requests     []RecordedRequest
+	unexpected   []RecordedRequest
+}
+
+// RecordedRequest is a request captured by ExpectTransport.
+type RecordedRequest struct {
+	Method string
+	Path   string
+	Query  url.Values
+	Header http.Header
+	Body   []byte
+}
+
+// BodyMatcher reports whether a request body matches an expectation.
+type BodyMatcher func(body []byte) bool
+
+// Expectation is a single registered response. Path patterns use path.Match
+// syntax, so "/dictionary/_doc/*" matches any document id.
+type Expectation struct {
+	t       *ExpectTransport
+	method  string
+	pattern string
+	body    BodyMatcher
+	status  int
+	payload []byte
+	times   int
+	calls   int
+}
+
+func NewExpectTransport() *ExpectTransport {
+	return &ExpectTransport{}
+}
+
+// CreateExpectClient returns an elasticsearch client backed by the given ExpectTransport.
+func CreateExpectClient(mock *ExpectTransport) (*elasticsearch.Client, error) {
+	return elasticsearch.NewClient(elasticsearch.Config{
+		Transport: mock,
+	})
+}
+
+// Expect registers an expectation for method and path pattern. It answers once
+// with an empty 200 response unless configured otherwise.
+func (t *ExpectTransport) Expect(method, pattern string) *Expectation {
+	if !strings.HasPrefix(pattern, "/") {
+		pattern = "/" + pattern
+	}
+
+	e := &Expectation{
+		t:       t,
+		method:  strings.ToUpper(method),
+		pattern: pattern,
+		status:  http.StatusOK,
+		payload: []byte("{}"),
+		times:   1,
+	}
+
+	t.mu.Lock()
+	t.expectations = append(t.expectations, e)
+	t.mu.Unlock()
+
+	return e
+}
+
+// WithBody restricts the expectation to requests whose body matches.
+func (e *Expectation) WithBody(matcher BodyMatcher) *Expectation {
+	e.t.mu.Lock()
+	defer e.t.mu.Unlock()
+
+	e.body = matcher
+	return e
+}
+
+// RespondWith sets the status code and raw body of the response.
+func (e *Expectation) RespondWith(status int, body []byte) *Expectation {
+	e.t.mu.Lock()
+	defer e.t.mu.Unlock()
+
+	e.status = status
+	e.payload = body
+	return e
+}
+
+// RespondWithFixture answers with a fixture from the eratosthenes directory or
+// one added with AddRawFixture.
+func (e *Expectation) RespondWithFixture(status int, name string) *Expectation {
+	if !strings.Contains(name, ".json") {
+		name = fmt.Sprintf("%s.json", name)
+	}
+	data, ok := fixtures[name]
+	if !ok {
+		panic(fmt.Sprintf("Fixture not found: %s", name))
+	}
+
+	return e.RespondWith(status, data)
+}
+
+// Times sets how often the expectation must be matched.
+func (e *Expectation) Times(n int) *Expectation {
+	e.t.mu.Lock()
+	defer e.t.mu.Unlock()
+
+	e.times = n
+	return e
+}
+
+// AnyTimes lets the expectation match any number of requests, including none.
+func (e *Expectation) AnyTimes() *Expectation {
+	e.t.mu.Lock()
+	defer e.t.mu.Unlock()
+
+	e.times = -1
+	return e
+}
+
+func (e *Expectation) matches(req RecordedRequest) bool {
+	if e.times >= 0 && e.calls >= e.times {
+		return false
+	}
+	if e.method != req.Method {
+		return false
+	}
+	if ok, err := path.Match(e.pattern, req.Path); err != nil || !ok {
+		return false
+	}
+	if e.body != nil && !e.body(req.Body) {
+		return false
+	}
+
+	return true
+}
+
+func (e *Expectation) String() string {
+	return fmt.Sprintf("%s %s", e.method, e.pattern)
+}
+
+// BodyContains matches bodies that contain substr.
+func BodyContains(substr string) BodyMatcher {
+	return func(body []byte) bool {
+		return bytes.Contains(body, []byte(substr))
+	}
+}
+
+// BodyJSONEq matches bodies that are JSON equal to expected, which may be raw
+// JSON bytes, a string or any value that marshals to JSON.
+func BodyJSONEq(expected interface{}) BodyMatcher {
+	var raw []byte
+	switch e := expected.(type) {
+	case []byte:
+		raw = e
+	case string:
+		raw = []byte(e)
+	default:
+		var err error
+		if raw, err = json.Marshal(e); err != nil {
+			panic(fmt.Sprintf("cannot marshal expected body: %s", err))
+		}
+	}
+
+	var want interface{}
+	if err := json.Unmarshal(raw, &want); err != nil {
+		panic(fmt.Sprintf("expected body is not valid json: %s", err))
+	}
+
+	return func(body []byte) bool {
+		var got interface{}
+		if err := json.Unmarshal(body, &got); err != nil {
+			return false
+		}
+		return reflect.DeepEqual(want, got)
+	}
+}
+
+func (t *ExpectTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	var body []byte
+	if req.Body != nil {
+		var err error
+		body, err = io.ReadAll(req.Body)
+		req.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	recorded := RecordedRequest{
+		Method: req.Method,
+		Path:   req.URL.Path,
+		Query:  req.URL.Query(),
+		Header: req.Header.Clone(),
+		Body:   body,
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.requests = append(t.requests, recorded)
+	for _, e := range t.expectations {
+		if e.matches(recorded) {
+			e.calls++
+			return expectResponse(req, e.status, e.payload), nil
+		}
+	}
+
+	t.unexpected = append(t.unexpected, recorded)
+	payload, _ := json.Marshal(map[string]interface{}{
+		"error": map[string]interface{}{
+			"type":   "aristoteles_mock_exception",
+			"reason": fmt.Sprintf("no expectation matches %s %s", req.Method, req.URL.Path),
+		},
+		"status": http.StatusInternalServerError,
+	})
+
+	return expectResponse(req, http.StatusInternalServerError, payload), nil
+}
+
+func expectResponse(req *http.Request, status int, payload []byte) *http.Response {
+	return &http.Response{
+		StatusCode:    status,
+		Status:        fmt.Sprintf("%d %s", status, http.StatusText(status)),
+		Header:        http.Header{"X-Elastic-Product": []string{"Elasticsearch"}, "Content-Type": []string{"application/json"}},
+		Body:          io.NopCloser(bytes.NewReader(payload)),
+		ContentLength: int64(len(payload)),
+		Request:       req,
+	}
+}
+
+// Requests returns every request the transport has seen, in order.
+func (t *ExpectTransport) Requests() []RecordedRequest {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	return append([]RecordedRequest(nil), t.requests...)
+}
+
+// ExpectationsMet returns an error listing expectations that were not matched
+// often enough and requests that matched no expectation.
+func (t *ExpectTransport) ExpectationsMet() error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	var problems []string
+	for _, e := range t.expectations {
+		if e.times >= 0 && e.calls < e.times {
+			problems = append(problems, fmt.Sprintf("expected %s %d time(s), got %d", e, e.times, e.calls))
+		}
+	}
+	for _, req := range t.unexpected {
+		problems = append(problems, fmt.Sprintf("unexpected request %s %s", req.Method, req.Path))
+	}
+
+	if len(problems) == 0 {
+		return nil
+	}
+
+	return fmt.Errorf("mock expectations not met:\n\t%s", strings.Join(problems, "\n\t"))
+}
+
+// AssertExpectations fails the test when ExpectationsMet returns an error.
+func (t *ExpectTransport) AssertExpectations(tb interface {
+	Helper()
+	Errorf(format string, args ...interface{})
+}) bool {
+	tb.Helper()
+	if err := t.ExpectationsMet(); err != nil {
+		tb.Errorf("%s", err)
+		return false
+	}
+
+	return true
+}
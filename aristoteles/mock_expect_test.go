@@ -0,0 +1,202 @@
+package aristoteles
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"unicode/utf8"
+
+	"github.com/odysseia-greek/agora/aristoteles/models"
+	"github.com/stretchr/testify/assert"
+)
+
+type recordingTB struct {
+	errors []string
+}
+
+func (r *recordingTB) Helper() {}
+
+func (r *recordingTB) Errorf(format string, args ...interface{}) {
+	r.errors = append(r.errors, format)
+}
+
+func TestExpectTransport(t *testing.T) {
+	index := "dictionary"
+
+	t.Run("MatchesOnMethodAndPath", func(t *testing.T) {
+		mock := NewExpectTransport()
+		mock.Expect(http.MethodGet, "/dictionary/_doc/*").RespondWithFixture(200, "byId")
+		testClient, err := NewExpectClient(mock)
+		assert.Nil(t, err)
+
+		res, err := testClient.Query().GetById(context.Background(), index, "logos")
+		assert.Nil(t, err)
+		assert.NotNil(t, res)
+		assert.Nil(t, mock.ExpectationsMet())
+
+		requests := mock.Requests()
+		assert.Len(t, requests, 1)
+		assert.Equal(t, "/dictionary/_doc/logos", requests[0].Path)
+	})
+
+	t.Run("MatchesOnBody", func(t *testing.T) {
+		mock := NewExpectTransport()
+		mock.Expect(http.MethodPost, "/dictionary/_search").
+			WithBody(BodyContains("ἀγάπη")).
+			RespondWith(404, fixtures["error.json"])
+		mock.Expect(http.MethodPost, "/dictionary/_search").
+			WithBody(BodyJSONEq(map[string]interface{}{"query": map[string]interface{}{"match_phrase": map[string]interface{}{"greek": "λόγος"}}})).
+			RespondWithFixture(200, "searchWord")
+		testClient, err := NewExpectClient(mock)
+		assert.Nil(t, err)
+
+		res, err := testClient.Query().Match(index, NewBuilderImpl().MatchQuery("greek", "λόγος"))
+		assert.Nil(t, err)
+		assert.NotNil(t, res)
+
+		_, err = testClient.Query().Match(index, NewBuilderImpl().MatchQuery("greek", "ἀγάπη"))
+		var elasticErr *ElasticError
+		assert.True(t, errors.As(err, &elasticErr))
+		assert.Equal(t, 404, elasticErr.StatusCode)
+
+		assert.Nil(t, mock.ExpectationsMet())
+		assert.Len(t, mock.Requests(), 2)
+	})
+
+	t.Run("Times", func(t *testing.T) {
+		mock := NewExpectTransport()
+		mock.Expect(http.MethodDelete, "/dictionary").RespondWithFixture(200, "deleteIndex").Times(2)
+		mock.Expect(http.MethodGet, "/dictionary").AnyTimes()
+		testClient, err := NewExpectClient(mock)
+		assert.Nil(t, err)
+
+		deleted, err := testClient.Index().Delete(index)
+		assert.Nil(t, err)
+		assert.True(t, deleted)
+
+		err = mock.ExpectationsMet()
+		assert.NotNil(t, err)
+		assert.Contains(t, err.Error(), "expected DELETE /dictionary 2 time(s), got 1")
+	})
+
+	t.Run("Unexpected", func(t *testing.T) {
+		mock := NewExpectTransport()
+		testClient, err := NewExpectClient(mock)
+		assert.Nil(t, err)
+
+		_, err = testClient.Index().Delete(index)
+		var elasticErr *ElasticError
+		assert.True(t, errors.As(err, &elasticErr))
+		assert.Equal(t, 500, elasticErr.StatusCode)
+
+		tb := &recordingTB{}
+		assert.False(t, mock.AssertExpectations(tb))
+		assert.Len(t, tb.errors, 1)
+		assert.Contains(t, mock.ExpectationsMet().Error(), "unexpected request DELETE /dictionary")
+	})
+
+	t.Run("ConfigureWhileServing", func(t *testing.T) {
+		mock := NewExpectTransport()
+		expectation := mock.Expect(http.MethodGet, "/dictionary").AnyTimes()
+		testClient, err := NewExpectClient(mock)
+		assert.Nil(t, err)
+
+		var wg sync.WaitGroup
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < 50; i++ {
+				testClient.Index().IndexExists(index)
+			}
+		}()
+		for i := 0; i < 50; i++ {
+			expectation.RespondWith(http.StatusOK, []byte("{}")).AnyTimes()
+		}
+		wg.Wait()
+
+		assert.Nil(t, mock.ExpectationsMet())
+	})
+}
+
+func TestMockTransportRoundTripFn(t *testing.T) {
+	var seen string
+	mockTrans := &MockTransport{
+		RoundTripFn: func(req *http.Request) (*http.Response, error) {
+			seen = req.URL.Path
+			return expectResponse(req, http.StatusOK, fixtures["byId.json"]), nil
+		},
+	}
+
+	res, err := mockTrans.RoundTrip(httptest.NewRequest(http.MethodGet, "/dictionary/_doc/logos", nil))
+	assert.Nil(t, err)
+	assert.Equal(t, http.StatusOK, res.StatusCode)
+	assert.Equal(t, "/dictionary/_doc/logos", seen)
+
+	t.Run("CallsBackIntoRoundTrip", func(t *testing.T) {
+		var calls int
+		replay := &MockTransport{Responses: []*http.Response{expectResponse(nil, http.StatusOK, fixtures["byId.json"])}}
+		replay.RoundTripFn = func(req *http.Request) (*http.Response, error) {
+			calls++
+			return replay.RoundTrip(req)
+		}
+
+		res, err := replay.RoundTrip(httptest.NewRequest(http.MethodGet, "/dictionary/_doc/logos", nil))
+		assert.Nil(t, err)
+		assert.Equal(t, http.StatusOK, res.StatusCode)
+		assert.Equal(t, 1, calls)
+	})
+}
+
+func TestRecordingTransport(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Elastic-Product", "Elasticsearch")
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"count":3,"_shards":{"total":1}}`))
+	}))
+	defer server.Close()
+
+	dir := t.TempDir()
+	recorder := NewRecordingTransport(nil, dir)
+	base, err := http.NewRequest(http.MethodGet, server.URL+"/dictionary/_count", nil)
+	assert.Nil(t, err)
+
+	for i := 0; i < 2; i++ {
+		res, err := recorder.RoundTrip(base)
+		assert.Nil(t, err)
+		res.Body.Close()
+	}
+
+	recorded := recorder.Recorded()
+	assert.Len(t, recorded, 2)
+	assert.Equal(t, "getDictionaryCount.json", recorded[0].Name)
+	assert.Equal(t, "getDictionaryCount2.json", recorded[1].Name)
+	assert.Equal(t, http.StatusOK, recorded[0].Status)
+
+	data, err := os.ReadFile(filepath.Join(dir, "getDictionaryCount.json"))
+	assert.Nil(t, err)
+	assert.Equal(t, "{\n  \"count\": 3,\n  \"_shards\": {\n    \"total\": 1\n  }\n}", string(data))
+
+	t.Run("Client", func(t *testing.T) {
+		dir := t.TempDir()
+		config := models.Config{Service: server.URL}
+		testClient, recorder, err := NewRecordingClient(config, dir)
+		assert.Nil(t, err)
+
+		count, err := testClient.Query().CountRaw(context.Background(), "dictionary", nil)
+		assert.Nil(t, err)
+		assert.Equal(t, int64(3), count.Count)
+		assert.Len(t, recorder.Recorded(), 1)
+	})
+
+	t.Run("FixtureNames", func(t *testing.T) {
+		assert.Equal(t, "getDictionarySearch", fixtureName(http.MethodGet, "/dictionary/_search"))
+		assert.Equal(t, "getDictionaryDocΛόγος", fixtureName(http.MethodGet, "/dictionary/_doc/λόγος"))
+		assert.Equal(t, "putἈρετή", fixtureName(http.MethodPut, "/ἀρετή"))
+		assert.True(t, utf8.ValidString(fixtureName(http.MethodGet, "/dictionary/_doc/ἀγάπη")))
+	})
+}
@@ -0,0 +1,168 @@
+package aristoteles
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/odysseia-greek/agora/aristoteles/models"
+)
+
+// RecordingTransport forwards requests to a real cluster and writes every
+// response body into Dir as an eratosthenes style fixture, so the files can be
+// replayed with NewMockClient or RespondWithFixture.
+//
+// Fixtures are named after the method and path, "GET /dictionary/_search"
+// becomes getDictionarySearch.json; repeated requests get a numeric suffix.
+// Set Name to choose the name per request instead.
+type RecordingTransport struct {
+	Base http.RoundTripper
+	Dir  string
+	Name func(req *http.Request) string
+
+	mu       sync.Mutex
+	seen     map[string]int
+	recorded []RecordedFixture
+}
+
+// RecordedFixture describes a fixture file written by RecordingTransport.
+type RecordedFixture struct {
+	Name   string
+	Method string
+	Path   string
+	Status int
+}
+
+func NewRecordingTransport(base http.RoundTripper, dir string) *RecordingTransport {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+
+	return &RecordingTransport{
+		Base: base,
+		Dir:  dir,
+		seen: make(map[string]int),
+	}
+}
+
+// NewRecordingClient connects to the cluster described by config and records
+// every response into dir.
+func NewRecordingClient(config models.Config, dir string) (Client, *RecordingTransport, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, nil, err
+	}
+
+	base, err := createTransport(config)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	recorder := NewRecordingTransport(base, dir)
+	config.Transport = recorder
+	config.ElasticCERT = ""
+	config.ClientCERT = ""
+	config.ClientKEY = ""
+	config.CertificateFingerprint = ""
+
+	client, err := NewClient(config)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return client, recorder, nil
+}
+
+func (t *RecordingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	res, err := t.Base.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := io.ReadAll(res.Body)
+	res.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	res.Body = io.NopCloser(bytes.NewReader(body))
+
+	if err := t.record(req, res.StatusCode, body); err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// Recorded returns the fixtures written so far, in order.
+func (t *RecordingTransport) Recorded() []RecordedFixture {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	return append([]RecordedFixture(nil), t.recorded...)
+}
+
+func (t *RecordingTransport) record(req *http.Request, status int, body []byte) error {
+	base := fixtureName(req.Method, req.URL.Path)
+	if t.Name != nil {
+		base = t.Name(req)
+	}
+	base = strings.TrimSuffix(base, ".json")
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.seen == nil {
+		t.seen = make(map[string]int)
+	}
+	t.seen[base]++
+	name := base
+	if n := t.seen[base]; n > 1 {
+		name = fmt.Sprintf("%s%d", base, n)
+	}
+	name += ".json"
+
+	var pretty bytes.Buffer
+	if err := json.Indent(&pretty, body, "", "  "); err != nil {
+		pretty.Reset()
+		pretty.Write(body)
+	}
+	if err := os.WriteFile(filepath.Join(t.Dir, name), pretty.Bytes(), 0o644); err != nil {
+		return fmt.Errorf("cannot write fixture %s: %w", name, err)
+	}
+
+	t.recorded = append(t.recorded, RecordedFixture{
+		Name:   name,
+		Method: req.Method,
+		Path:   req.URL.Path,
+		Status: status,
+	})
+
+	return nil
+}
+
+// fixtureName turns a method and path into a camelCase fixture name, dropping
+// the leading underscore of API endpoints: "GET /dictionary/_search" becomes
+// getDictionarySearch.
+func fixtureName(method, urlPath string) string {
+	var b strings.Builder
+	b.WriteString(strings.ToLower(method))
+
+	for _, segment := range strings.Split(urlPath, "/") {
+		for _, word := range strings.FieldsFunc(segment, func(r rune) bool {
+			return r == '_' || r == '-' || r == '.' || r == ',' || r == '*'
+		}) {
+			first, size := utf8.DecodeRuneInString(word)
+			b.WriteRune(unicode.ToUpper(first))
+			b.WriteString(word[size:])
+		}
+	}
+
+	return b.String()
+}
@@ -0,0 +1,19 @@
+package models
+
+// DocumentVersion identifies the last write to a document. Passing it back on
+// a write makes the write fail with a version conflict if the document has
+// changed since.
+type DocumentVersion struct {
+	SeqNo       int64 `json:"_seq_no"`
+	PrimaryTerm int64 `json:"_primary_term"`
+}
+
+// DocumentVersion returns the version of the document as it was read.
+func (r DirectResponse) DocumentVersion() DocumentVersion {
+	return DocumentVersion{SeqNo: int64(r.SeqNo), PrimaryTerm: int64(r.PrimaryTerm)}
+}
+
+// DocumentVersion returns the version of the document after the write.
+func (r CreateResult) DocumentVersion() DocumentVersion {
+	return DocumentVersion{SeqNo: r.SeqNo, PrimaryTerm: r.PrimaryTerm}
+}
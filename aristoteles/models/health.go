@@ -17,4 +17,6 @@ type DatabaseHealth struct {
 	ClusterName   string `json:"clusterName,omitempty"`
 	ServerName    string `json:"serverName,omitempty"`
 	ServerVersion string `json:"serverVersion,omitempty"`
+	// CircuitBreaker is empty when the client runs without a circuit breaker.
+	CircuitBreaker string `json:"circuitBreaker,omitempty"`
 }
@@ -1,6 +1,10 @@
 package models
 
-import "encoding/json"
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+)
 
 func UnmarshalResponse(data []byte) (Response, error) {
 	var r Response
@@ -42,6 +46,7 @@ type Hit struct {
 	ID     string                 `json:"_id"`
 	Score  float64                `json:"_score"`
 	Source map[string]interface{} `json:"_source"`
+	Sort   []interface{}          `json:"sort,omitempty"`
 }
 
 type Total struct {
@@ -164,10 +169,41 @@ type CreateUserRequest struct {
 }
 
 type Config struct {
-	Service     string `json:"elasticService"`
-	Username    string `json:"elasticUsername"`
-	Password    string `json:"elasticPassword"`
-	ElasticCERT string `json:"elasticCert"`
+	Service     string `json:"elasticService" yaml:"service"`
+	Username    string `json:"elasticUsername" yaml:"username"`
+	Password    string `json:"elasticPassword" yaml:"password"`
+	ElasticCERT string `json:"elasticCert" yaml:"caCert"`
+
+	// Nodes takes precedence over Service when set.
+	Nodes                 []string      `json:"elasticNodes,omitempty" yaml:"nodes"`
+	DiscoverNodesOnStart  bool          `json:"discoverNodesOnStart,omitempty" yaml:"discoverNodesOnStart"`
+	DiscoverNodesInterval time.Duration `json:"discoverNodesInterval,omitempty" yaml:"discoverNodesInterval"`
+
+	// APIKey is the base64 encoded "id:api_key" pair. APIKey wins over
+	// ServiceToken, which wins over Username/Password.
+	APIKey       string `json:"elasticApiKey,omitempty" yaml:"apiKey"`
+	ServiceToken string `json:"elasticServiceToken,omitempty" yaml:"serviceToken"`
+
+	// CertificateFingerprint pins the SHA256 fingerprint of the CA instead of
+	// trusting ElasticCERT, in hex with or without colons. It needs Transport to
+	// be nil or an *http.Transport.
+	CertificateFingerprint string `json:"certificateFingerprint,omitempty" yaml:"certificateFingerprint"`
+	// ClientCERT and ClientKEY are PEM encoded and enable mutual TLS.
+	ClientCERT string `json:"clientCert,omitempty" yaml:"clientCert"`
+	ClientKEY  string `json:"clientKey,omitempty" yaml:"clientKey"`
+
+	CompressRequestBody bool `json:"compressRequestBody,omitempty" yaml:"compressRequestBody"`
+
+	// Transport replaces the default HTTP transport. TLS settings are only
+	// applied to it when it is an *http.Transport.
+	Transport http.RoundTripper `json:"-" yaml:"-"`
+}
+
+func (c Config) Addresses() []string {
+	if len(c.Nodes) > 0 {
+		return c.Nodes
+	}
+	return []string{c.Service}
 }
 
 func UnmarshalCreateResult(data []byte) (CreateResult, error) {
@@ -240,3 +276,21 @@ type IndexInfo struct {
 	TotalDocuments int64                  `json:"total_documents,omitempty"`
 	SizeInBytes    int64                  `json:"size_in_bytes,omitempty"`
 }
+
+type AnalyzeResponse struct {
+	Tokens []AnalyzeToken `json:"tokens"`
+}
+
+type AnalyzeToken struct {
+	Token       string `json:"token"`
+	StartOffset int    `json:"start_offset"`
+	EndOffset   int    `json:"end_offset"`
+	Type        string `json:"type"`
+	Position    int    `json:"position"`
+}
+
+func UnmarshalAnalyzeResponse(data []byte) (AnalyzeResponse, error) {
+	var r AnalyzeResponse
+	err := json.Unmarshal(data, &r)
+	return r, err
+}
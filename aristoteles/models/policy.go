@@ -0,0 +1,97 @@
+package models
+
+import "encoding/json"
+
+type ILMPolicy struct {
+	Phases ILMPhases              `json:"phases"`
+	Meta   map[string]interface{} `json:"_meta,omitempty"`
+}
+
+func (r *ILMPolicy) Marshal() ([]byte, error) {
+	return json.Marshal(map[string]interface{}{"policy": r})
+}
+
+type ILMPhases struct {
+	Hot    *ILMPhase `json:"hot,omitempty"`
+	Warm   *ILMPhase `json:"warm,omitempty"`
+	Cold   *ILMPhase `json:"cold,omitempty"`
+	Frozen *ILMPhase `json:"frozen,omitempty"`
+	Delete *ILMPhase `json:"delete,omitempty"`
+}
+
+type ILMPhase struct {
+	MinAge  string     `json:"min_age,omitempty"`
+	Actions ILMActions `json:"actions"`
+}
+
+type ILMActions struct {
+	Rollover    *RolloverAction    `json:"rollover,omitempty"`
+	Shrink      *ShrinkAction      `json:"shrink,omitempty"`
+	ForceMerge  *ForceMergeAction  `json:"forcemerge,omitempty"`
+	SetPriority *SetPriorityAction `json:"set_priority,omitempty"`
+	Delete      *DeleteAction      `json:"delete,omitempty"`
+}
+
+type RolloverAction struct {
+	MaxAge              string `json:"max_age,omitempty"`
+	MaxSize             string `json:"max_size,omitempty"`
+	MaxDocs             int64  `json:"max_docs,omitempty"`
+	MaxPrimaryShardSize string `json:"max_primary_shard_size,omitempty"`
+}
+
+type ShrinkAction struct {
+	NumberOfShards      int    `json:"number_of_shards,omitempty"`
+	MaxPrimaryShardSize string `json:"max_primary_shard_size,omitempty"`
+}
+
+type ForceMergeAction struct {
+	MaxNumSegments int `json:"max_num_segments"`
+}
+
+type SetPriorityAction struct {
+	Priority int `json:"priority"`
+}
+
+type DeleteAction struct {
+	DeleteSearchableSnapshot *bool `json:"delete_searchable_snapshot,omitempty"`
+}
+
+type ILMPolicyInfo struct {
+	Version      int64     `json:"version"`
+	ModifiedDate string    `json:"modified_date"`
+	Policy       ILMPolicy `json:"policy"`
+	InUseBy      struct {
+		Indices             []string `json:"indices"`
+		DataStreams         []string `json:"data_streams"`
+		ComposableTemplates []string `json:"composable_templates"`
+	} `json:"in_use_by"`
+}
+
+func UnmarshalILMPolicies(data []byte) (map[string]ILMPolicyInfo, error) {
+	var r map[string]ILMPolicyInfo
+	err := json.Unmarshal(data, &r)
+	return r, err
+}
+
+type ILMExplainResponse struct {
+	Indices map[string]ILMIndexExplain `json:"indices"`
+}
+
+type ILMIndexExplain struct {
+	Index               string                 `json:"index"`
+	Managed             bool                   `json:"managed"`
+	Policy              string                 `json:"policy,omitempty"`
+	LifecycleDateMillis int64                  `json:"lifecycle_date_millis,omitempty"`
+	Age                 string                 `json:"age,omitempty"`
+	Phase               string                 `json:"phase,omitempty"`
+	Action              string                 `json:"action,omitempty"`
+	Step                string                 `json:"step,omitempty"`
+	FailedStep          string                 `json:"failed_step,omitempty"`
+	StepInfo            map[string]interface{} `json:"step_info,omitempty"`
+}
+
+func UnmarshalILMExplainResponse(data []byte) (ILMExplainResponse, error) {
+	var r ILMExplainResponse
+	err := json.Unmarshal(data, &r)
+	return r, err
+}
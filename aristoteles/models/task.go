@@ -0,0 +1,59 @@
+package models
+
+import "encoding/json"
+
+type Script struct {
+	Source string                 `json:"source"`
+	Lang   string                 `json:"lang,omitempty"`
+	Params map[string]interface{} `json:"params,omitempty"`
+}
+
+type TaskSubmitResponse struct {
+	Task string `json:"task"`
+}
+
+func UnmarshalTaskSubmitResponse(data []byte) (TaskSubmitResponse, error) {
+	var r TaskSubmitResponse
+	err := json.Unmarshal(data, &r)
+	return r, err
+}
+
+// ByQueryResponse is the result of _reindex, _update_by_query and
+// _delete_by_query, either returned directly or as the response of a task.
+type ByQueryResponse struct {
+	Took             int64             `json:"took"`
+	TimedOut         bool              `json:"timed_out"`
+	Total            int64             `json:"total"`
+	Updated          int64             `json:"updated"`
+	Created          int64             `json:"created"`
+	Deleted          int64             `json:"deleted"`
+	Batches          int64             `json:"batches"`
+	VersionConflicts int64             `json:"version_conflicts"`
+	Noops            int64             `json:"noops"`
+	Failures         []json.RawMessage `json:"failures"`
+}
+
+func UnmarshalByQueryResponse(data []byte) (ByQueryResponse, error) {
+	var r ByQueryResponse
+	err := json.Unmarshal(data, &r)
+	return r, err
+}
+
+type TaskStatus struct {
+	Completed bool `json:"completed"`
+	Task      struct {
+		Node        string                 `json:"node"`
+		ID          int64                  `json:"id"`
+		Action      string                 `json:"action"`
+		Description string                 `json:"description"`
+		Status      map[string]interface{} `json:"status"`
+	} `json:"task"`
+	Response *ByQueryResponse       `json:"response,omitempty"`
+	Error    map[string]interface{} `json:"error,omitempty"`
+}
+
+func UnmarshalTaskStatus(data []byte) (TaskStatus, error) {
+	var r TaskStatus
+	err := json.Unmarshal(data, &r)
+	return r, err
+}
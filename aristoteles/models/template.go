@@ -0,0 +1,114 @@
+package models
+
+import "encoding/json"
+
+type IndexTemplate struct {
+	IndexPatterns []string               `json:"index_patterns"`
+	ComposedOf    []string               `json:"composed_of,omitempty"`
+	Priority      *int                   `json:"priority,omitempty"`
+	Version       *int64                 `json:"version,omitempty"`
+	Template      *TemplateBody          `json:"template,omitempty"`
+	DataStream    map[string]interface{} `json:"data_stream,omitempty"`
+	Meta          map[string]interface{} `json:"_meta,omitempty"`
+}
+
+type ComponentTemplate struct {
+	Template TemplateBody           `json:"template"`
+	Version  *int64                 `json:"version,omitempty"`
+	Meta     map[string]interface{} `json:"_meta,omitempty"`
+}
+
+type TemplateBody struct {
+	Settings map[string]interface{}     `json:"settings,omitempty"`
+	Mappings map[string]interface{}     `json:"mappings,omitempty"`
+	Aliases  map[string]AliasDefinition `json:"aliases,omitempty"`
+}
+
+type AliasDefinition struct {
+	Filter        map[string]interface{} `json:"filter,omitempty"`
+	Routing       string                 `json:"routing,omitempty"`
+	IsWriteIndex  *bool                  `json:"is_write_index,omitempty"`
+	IsHidden      *bool                  `json:"is_hidden,omitempty"`
+	SearchRouting string                 `json:"search_routing,omitempty"`
+	IndexRouting  string                 `json:"index_routing,omitempty"`
+}
+
+type IndexTemplatesResponse struct {
+	IndexTemplates []struct {
+		Name          string        `json:"name"`
+		IndexTemplate IndexTemplate `json:"index_template"`
+	} `json:"index_templates"`
+}
+
+func UnmarshalIndexTemplatesResponse(data []byte) (IndexTemplatesResponse, error) {
+	var r IndexTemplatesResponse
+	err := json.Unmarshal(data, &r)
+	return r, err
+}
+
+type ComponentTemplatesResponse struct {
+	ComponentTemplates []struct {
+		Name              string            `json:"name"`
+		ComponentTemplate ComponentTemplate `json:"component_template"`
+	} `json:"component_templates"`
+}
+
+func UnmarshalComponentTemplatesResponse(data []byte) (ComponentTemplatesResponse, error) {
+	var r ComponentTemplatesResponse
+	err := json.Unmarshal(data, &r)
+	return r, err
+}
+
+// AliasAction is a single entry of an _aliases request. Exactly one of the
+// fields should be set.
+type AliasAction struct {
+	Add         *AliasActionBody `json:"add,omitempty"`
+	Remove      *AliasActionBody `json:"remove,omitempty"`
+	RemoveIndex *AliasActionBody `json:"remove_index,omitempty"`
+}
+
+type AliasActionBody struct {
+	Index        string                 `json:"index,omitempty"`
+	Indices      []string               `json:"indices,omitempty"`
+	Alias        string                 `json:"alias,omitempty"`
+	Filter       map[string]interface{} `json:"filter,omitempty"`
+	IsWriteIndex *bool                  `json:"is_write_index,omitempty"`
+	MustExist    *bool                  `json:"must_exist,omitempty"`
+}
+
+func AddAliasAction(index, alias string) AliasAction {
+	return AliasAction{Add: &AliasActionBody{Index: index, Alias: alias}}
+}
+
+func RemoveAliasAction(index, alias string) AliasAction {
+	return AliasAction{Remove: &AliasActionBody{Index: index, Alias: alias}}
+}
+
+func RemoveIndexAction(index string) AliasAction {
+	return AliasAction{RemoveIndex: &AliasActionBody{Index: index}}
+}
+
+type RolloverConditions struct {
+	MaxAge              string `json:"max_age,omitempty"`
+	MaxDocs             int64  `json:"max_docs,omitempty"`
+	MaxSize             string `json:"max_size,omitempty"`
+	MaxPrimaryShardSize string `json:"max_primary_shard_size,omitempty"`
+	MaxPrimaryShardDocs int64  `json:"max_primary_shard_docs,omitempty"`
+	MinDocs             int64  `json:"min_docs,omitempty"`
+}
+
+type RolloverResult struct {
+	Acknowledged       bool            `json:"acknowledged"`
+	ShardsAcknowledged bool            `json:"shards_acknowledged"`
+	OldIndex           string          `json:"old_index"`
+	NewIndex           string          `json:"new_index"`
+	RolledOver         bool            `json:"rolled_over"`
+	DryRun             bool            `json:"dry_run"`
+	Conditions         map[string]bool `json:"conditions"`
+}
+
+func UnmarshalRolloverResult(data []byte) (RolloverResult, error) {
+	var r RolloverResult
+	err := json.Unmarshal(data, &r)
+	return r, err
+}
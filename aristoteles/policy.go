@@ -4,7 +4,6 @@ import (
 	"bytes"
 	"context"
 	"fmt"
-	"io"
 
 	"github.com/elastic/go-elasticsearch/v9"
 	"github.com/elastic/go-elasticsearch/v9/esapi"
@@ -24,21 +23,14 @@ func (p *PolicyImpl) CreatePolicyWithRollOver(name, maxAge, phase string) (*mode
 }
 
 func (p *PolicyImpl) CreatePolicyWithRollOverWithContext(ctx context.Context, name, maxAge, phase string) (*models.IndexCreateResult, error) {
-	policyDefinition := fmt.Sprintf(`{
-		"policy": {
-			"phases": {
-				"%s": {
-					"actions": {
-						"rollover": {
-							"max_age": "%sd"
-						}
-					}
-				}
-			}
-		}
-	}`, phase, maxAge)
-
-	return p.create(ctx, name, policyDefinition)
+	policy, err := singlePhasePolicy(phase, models.ILMActions{
+		Rollover: &models.RolloverAction{MaxAge: fmt.Sprintf("%sd", maxAge)},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return p.PutPolicy(ctx, name, *policy)
 }
 
 func (p *PolicyImpl) CreatePolicy(name, phase string) (*models.IndexCreateResult, error) {
@@ -46,37 +38,108 @@ func (p *PolicyImpl) CreatePolicy(name, phase string) (*models.IndexCreateResult
 }
 
 func (p *PolicyImpl) CreatePolicyWithContext(ctx context.Context, name, phase string) (*models.IndexCreateResult, error) {
-	policyDefinition := fmt.Sprintf(`{
-	"policy": {
-		"phases": {
-			"%s": {
-				"actions": {}
-			}
-		}
+	policy, err := singlePhasePolicy(phase, models.ILMActions{})
+	if err != nil {
+		return nil, err
 	}
-}`, phase)
 
-	return p.create(ctx, name, policyDefinition)
+	return p.PutPolicy(ctx, name, *policy)
 }
 
-func (p *PolicyImpl) create(ctx context.Context, name, policyDefinition string) (*models.IndexCreateResult, error) {
-	req := esapi.ILMPutLifecycleRequest{
-		Policy: name,
-		Body:   bytes.NewReader([]byte(policyDefinition)),
+func (p *PolicyImpl) PutPolicy(ctx context.Context, name string, policy models.ILMPolicy) (*models.IndexCreateResult, error) {
+	policyDefinition, err := policy.Marshal()
+	if err != nil {
+		return nil, err
+	}
+
+	return p.create(ctx, name, string(policyDefinition))
+}
+
+func (p *PolicyImpl) GetPolicy(ctx context.Context, name string) (*models.ILMPolicyInfo, error) {
+	policies, err := p.get(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+
+	policy, ok := policies[name]
+	if !ok {
+		return nil, fmt.Errorf("ilm policy %q missing from response", name)
+	}
+
+	return &policy, nil
+}
+
+func (p *PolicyImpl) ListPolicies(ctx context.Context) (map[string]models.ILMPolicyInfo, error) {
+	return p.get(ctx, "")
+}
+
+func (p *PolicyImpl) DeletePolicy(ctx context.Context, name string) (bool, error) {
+	jsonBody, err := perform(ctx, p.es, esapi.ILMDeleteLifecycleRequest{Policy: name}, "delete ilm policy")
+	if err != nil {
+		return false, err
 	}
 
-	res, err := req.Do(ctx, p.es)
+	elasticResult, err := models.UnmarshalIndexCreateResult(jsonBody)
+	if err != nil {
+		return false, err
+	}
+
+	return elasticResult.Acknowledged, nil
+}
+
+func (p *PolicyImpl) ExplainLifecycle(ctx context.Context, index string) (*models.ILMExplainResponse, error) {
+	jsonBody, err := perform(ctx, p.es, esapi.ILMExplainLifecycleRequest{Index: index}, "explain ilm lifecycle")
+	if err != nil {
+		return nil, err
+	}
+
+	explain, err := models.UnmarshalILMExplainResponse(jsonBody)
 	if err != nil {
 		return nil, err
 	}
-	defer res.Body.Close()
 
-	jsonBody, err := io.ReadAll(res.Body)
+	return &explain, nil
+}
+
+func (p *PolicyImpl) get(ctx context.Context, name string) (map[string]models.ILMPolicyInfo, error) {
+	jsonBody, err := perform(ctx, p.es, esapi.ILMGetLifecycleRequest{Policy: name}, "get ilm policy")
 	if err != nil {
 		return nil, err
 	}
-	if res.IsError() {
-		return nil, newElasticErrorFromBody("create ilm policy", res, jsonBody)
+
+	return models.UnmarshalILMPolicies(jsonBody)
+}
+
+func singlePhasePolicy(phase string, actions models.ILMActions) (*models.ILMPolicy, error) {
+	ilmPhase := &models.ILMPhase{Actions: actions}
+	policy := &models.ILMPolicy{}
+	switch phase {
+	case "hot":
+		policy.Phases.Hot = ilmPhase
+	case "warm":
+		policy.Phases.Warm = ilmPhase
+	case "cold":
+		policy.Phases.Cold = ilmPhase
+	case "frozen":
+		policy.Phases.Frozen = ilmPhase
+	case "delete":
+		policy.Phases.Delete = ilmPhase
+	default:
+		return nil, fmt.Errorf("unknown ilm phase %q", phase)
+	}
+
+	return policy, nil
+}
+
+func (p *PolicyImpl) create(ctx context.Context, name, policyDefinition string) (*models.IndexCreateResult, error) {
+	req := esapi.ILMPutLifecycleRequest{
+		Policy: name,
+		Body:   bytes.NewReader([]byte(policyDefinition)),
+	}
+
+	jsonBody, err := perform(ctx, p.es, req, "create ilm policy")
+	if err != nil {
+		return nil, err
 	}
 
 	elasticResult, err := models.UnmarshalIndexCreateResult(jsonBody)
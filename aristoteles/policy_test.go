@@ -0,0 +1,152 @@
+package aristoteles
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/odysseia-greek/agora/aristoteles/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPolicyModel(t *testing.T) {
+	t.Run("MarshalOmitsEmptyPhases", func(t *testing.T) {
+		policy := models.ILMPolicy{
+			Phases: models.ILMPhases{
+				Hot: &models.ILMPhase{
+					Actions: models.ILMActions{
+						Rollover:   &models.RolloverAction{MaxAge: "7d", MaxDocs: 1000},
+						ForceMerge: &models.ForceMergeAction{MaxNumSegments: 1},
+					},
+				},
+				Delete: &models.ILMPhase{
+					MinAge:  "30d",
+					Actions: models.ILMActions{Delete: &models.DeleteAction{}},
+				},
+			},
+		}
+
+		body, err := policy.Marshal()
+		assert.Nil(t, err)
+
+		var decoded map[string]map[string]map[string]interface{}
+		assert.Nil(t, json.Unmarshal(body, &decoded))
+		phases := decoded["policy"]["phases"]
+		assert.Contains(t, phases, "hot")
+		assert.Contains(t, phases, "delete")
+		assert.NotContains(t, phases, "warm")
+		assert.NotContains(t, phases, "cold")
+		assert.JSONEq(t, `{"actions":{"rollover":{"max_age":"7d","max_docs":1000},"forcemerge":{"max_num_segments":1}}}`, mustJSON(t, phases["hot"]))
+	})
+
+	t.Run("UnknownPhase", func(t *testing.T) {
+		testClient, err := NewMockClient("createIndex", 200)
+		assert.Nil(t, err)
+
+		created, err := testClient.Policy().CreatePolicyWithRollOver("test", "5", "archive")
+		assert.NotNil(t, err)
+		assert.Nil(t, created)
+	})
+
+	t.Run("AllPhases", func(t *testing.T) {
+		for _, phase := range []string{"hot", "warm", "cold", "frozen", "delete"} {
+			policy, err := singlePhasePolicy(phase, models.ILMActions{})
+			assert.Nil(t, err)
+
+			body, err := policy.Marshal()
+			assert.Nil(t, err)
+
+			var decoded map[string]map[string]map[string]interface{}
+			assert.Nil(t, json.Unmarshal(body, &decoded))
+			assert.Contains(t, decoded["policy"]["phases"], phase)
+		}
+	})
+}
+
+func TestPolicyClient(t *testing.T) {
+	ctx := context.Background()
+	name := "dictionary_policy"
+
+	t.Run("Put", func(t *testing.T) {
+		testClient, err := NewMockClient("createIndex", 200)
+		assert.Nil(t, err)
+
+		created, err := testClient.Policy().PutPolicy(ctx, name, models.ILMPolicy{
+			Phases: models.ILMPhases{Hot: &models.ILMPhase{Actions: models.ILMActions{SetPriority: &models.SetPriorityAction{Priority: 50}}}},
+		})
+		assert.Nil(t, err)
+		assert.True(t, created.Acknowledged)
+	})
+
+	t.Run("Get", func(t *testing.T) {
+		testClient, err := NewMockClient("getPolicy", 200)
+		assert.Nil(t, err)
+
+		policy, err := testClient.Policy().GetPolicy(ctx, name)
+		assert.Nil(t, err)
+		assert.Equal(t, int64(3), policy.Version)
+		assert.Equal(t, "30d", policy.Policy.Phases.Hot.Actions.Rollover.MaxAge)
+		assert.Equal(t, "50gb", policy.Policy.Phases.Hot.Actions.Rollover.MaxPrimaryShardSize)
+		assert.Equal(t, 100, policy.Policy.Phases.Hot.Actions.SetPriority.Priority)
+		assert.NotNil(t, policy.Policy.Phases.Delete.Actions.Delete)
+		assert.Nil(t, policy.Policy.Phases.Warm)
+		assert.Equal(t, []string{"dictionary-000001"}, policy.InUseBy.Indices)
+	})
+
+	t.Run("GetMissingFromResponse", func(t *testing.T) {
+		testClient, err := NewMockClient("getPolicy", 200)
+		assert.Nil(t, err)
+
+		policy, err := testClient.Policy().GetPolicy(ctx, "other")
+		assert.NotNil(t, err)
+		assert.Nil(t, policy)
+	})
+
+	t.Run("GetNotFound", func(t *testing.T) {
+		testClient, err := NewMockClient("deleteIndex404", 404)
+		assert.Nil(t, err)
+
+		policy, err := testClient.Policy().GetPolicy(ctx, name)
+		assert.NotNil(t, err)
+		assert.Nil(t, policy)
+	})
+
+	t.Run("List", func(t *testing.T) {
+		testClient, err := NewMockClient("getPolicy", 200)
+		assert.Nil(t, err)
+
+		policies, err := testClient.Policy().ListPolicies(ctx)
+		assert.Nil(t, err)
+		assert.Len(t, policies, 1)
+		assert.Contains(t, policies, name)
+	})
+
+	t.Run("Delete", func(t *testing.T) {
+		testClient, err := NewMockClient("deleteIndex", 200)
+		assert.Nil(t, err)
+
+		deleted, err := testClient.Policy().DeletePolicy(ctx, name)
+		assert.Nil(t, err)
+		assert.True(t, deleted)
+	})
+
+	t.Run("Explain", func(t *testing.T) {
+		testClient, err := NewMockClient("explainLifecycle", 200)
+		assert.Nil(t, err)
+
+		explain, err := testClient.Policy().ExplainLifecycle(ctx, "dictionary-000001")
+		assert.Nil(t, err)
+		index := explain.Indices["dictionary-000001"]
+		assert.True(t, index.Managed)
+		assert.Equal(t, name, index.Policy)
+		assert.Equal(t, "hot", index.Phase)
+		assert.Equal(t, "check-rollover-ready", index.Step)
+	})
+}
+
+func mustJSON(t *testing.T, v interface{}) string {
+	t.Helper()
+	b, err := json.Marshal(v)
+	assert.Nil(t, err)
+	return string(b)
+}
@@ -202,6 +202,9 @@ func (q *QueryImpl) MatchWithScrollWithContext(ctx context.Context, index string
 	}
 
 	scrollID := firstResponse.ScrollId
+	defer func() {
+		q.clearScroll(ctx, scrollID)
+	}()
 	elasticResult.Hits.Hits = append(elasticResult.Hits.Hits, firstResponse.Hits.Hits...)
 
 	if len(firstResponse.Hits.Hits) < 10 {
@@ -232,6 +235,9 @@ func (q *QueryImpl) MatchWithScrollWithContext(ctx context.Context, index string
 			return nil, err
 		}
 
+		if scrollResponse.ScrollId != "" {
+			scrollID = scrollResponse.ScrollId
+		}
 		if len(scrollResponse.Hits.Hits) == 0 {
 			break
 		}
@@ -242,6 +248,23 @@ func (q *QueryImpl) MatchWithScrollWithContext(ctx context.Context, index string
 	return &elasticResult, nil
 }
 
+// clearScroll releases the scroll context on the cluster instead of leaving it to expire.
+// It is best effort: the search result is already complete when it runs.
+func (q *QueryImpl) clearScroll(ctx context.Context, scrollID string) {
+	if scrollID == "" {
+		return
+	}
+
+	res, err := q.es.ClearScroll(
+		q.es.ClearScroll.WithContext(context.WithoutCancel(ctx)),
+		q.es.ClearScroll.WithScrollID(scrollID),
+	)
+	if err != nil {
+		return
+	}
+	res.Body.Close()
+}
+
 func (q *QueryImpl) MatchAggregate(index string, request map[string]interface{}) (*models.Aggregations, error) {
 	return q.MatchAggregateWithContext(context.Background(), index, request)
 }
@@ -161,6 +161,21 @@ func TestQueryClientMatchWithScroll(t *testing.T) {
 		assert.Equal(t, len(sut.Hits.Hits), 5)
 	})
 
+	t.Run("ClearScrollKeepsFixtureOrder", func(t *testing.T) {
+		testClient, err := NewMockClient([]string{"createQuestionSokrates", "byId"}, 200)
+		assert.Nil(t, err)
+
+		sut, err := testClient.Query().MatchWithScroll(index, testClient.Builder().MatchQuery(match, word))
+		assert.Nil(t, err)
+		assert.Equal(t, 5, len(sut.Hits.Hits))
+
+		// the clear scroll request did not use up the next fixture
+		doc, err := testClient.Query().GetById(context.Background(), index, "814Vt5sBEpCWf9Kb8agJ")
+		assert.Nil(t, err)
+		assert.True(t, doc.Found)
+		assert.Equal(t, "814Vt5sBEpCWf9Kb8agJ", doc.Id)
+	})
+
 	t.Run("Failed", func(t *testing.T) {
 		file := "serviceDown"
 		status := 502
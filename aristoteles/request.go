@@ -2,7 +2,12 @@ package aristoteles
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/elastic/go-elasticsearch/v9/esapi"
 )
 
 func toBuffer(query map[string]interface{}) (bytes.Buffer, error) {
@@ -13,3 +18,47 @@ func toBuffer(query map[string]interface{}) (bytes.Buffer, error) {
 
 	return buf, nil
 }
+
+func toJSONReader(v interface{}) (io.Reader, error) {
+	body, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+
+	return bytes.NewReader(body), nil
+}
+
+// perform runs an esapi request and returns the response body, turning any
+// non 2xx response into an *ElasticError for the given operation.
+func perform(ctx context.Context, transport esapi.Transport, request esapi.Request, operation string) ([]byte, error) {
+	res, err := request.Do(ctx, transport)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+
+	jsonBody, err := io.ReadAll(res.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	if res.IsError() {
+		return nil, newElasticErrorFromBody(operation, res, jsonBody)
+	}
+
+	return jsonBody, nil
+}
+
+func parseAcknowledged(jsonBody []byte) (bool, error) {
+	var r struct {
+		Acknowledged *bool `json:"acknowledged"`
+	}
+	if err := json.Unmarshal(jsonBody, &r); err != nil {
+		return false, fmt.Errorf("error parsing successful response: %w", err)
+	}
+	if r.Acknowledged == nil {
+		return false, fmt.Errorf("'acknowledged' field missing in response: %s", jsonBody)
+	}
+
+	return *r.Acknowledged, nil
+}
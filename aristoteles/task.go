@@ -0,0 +1,47 @@
+package aristoteles
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/elastic/go-elasticsearch/v9"
+	"github.com/elastic/go-elasticsearch/v9/esapi"
+	"github.com/odysseia-greek/agora/aristoteles/models"
+)
+
+const defaultTaskPollInterval = time.Second
+
+func waitForTask(ctx context.Context, es *elasticsearch.Client, taskID string, pollInterval time.Duration) (*models.TaskStatus, error) {
+	if pollInterval <= 0 {
+		pollInterval = defaultTaskPollInterval
+	}
+
+	ticker := time.NewTicker(pollInterval)
+	defer ticker.Stop()
+
+	for {
+		jsonBody, err := perform(ctx, es, esapi.TasksGetRequest{TaskID: taskID}, "get task")
+		if err != nil {
+			return nil, err
+		}
+
+		status, err := models.UnmarshalTaskStatus(jsonBody)
+		if err != nil {
+			return nil, err
+		}
+
+		if status.Completed {
+			if status.Error != nil {
+				return &status, fmt.Errorf("task %s failed: %v", taskID, status.Error["reason"])
+			}
+			return &status, nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
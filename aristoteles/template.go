@@ -0,0 +1,95 @@
+package aristoteles
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/elastic/go-elasticsearch/v9/esapi"
+	"github.com/odysseia-greek/agora/aristoteles/models"
+)
+
+func (i *IndexImpl) PutIndexTemplate(ctx context.Context, name string, template models.IndexTemplate) (bool, error) {
+	body, err := toJSONReader(template)
+	if err != nil {
+		return false, err
+	}
+
+	jsonBody, err := perform(ctx, i.es, esapi.IndicesPutIndexTemplateRequest{Name: name, Body: body}, "put index template")
+	if err != nil {
+		return false, err
+	}
+
+	return parseAcknowledged(jsonBody)
+}
+
+func (i *IndexImpl) GetIndexTemplate(ctx context.Context, name string) (*models.IndexTemplate, error) {
+	jsonBody, err := perform(ctx, i.es, esapi.IndicesGetIndexTemplateRequest{Name: name}, "get index template")
+	if err != nil {
+		return nil, err
+	}
+
+	templates, err := models.UnmarshalIndexTemplatesResponse(jsonBody)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, template := range templates.IndexTemplates {
+		if template.Name == name {
+			return &template.IndexTemplate, nil
+		}
+	}
+
+	return nil, fmt.Errorf("index template %q missing from response", name)
+}
+
+func (i *IndexImpl) DeleteIndexTemplate(ctx context.Context, name string) (bool, error) {
+	jsonBody, err := perform(ctx, i.es, esapi.IndicesDeleteIndexTemplateRequest{Name: []string{name}}, "delete index template")
+	if err != nil {
+		return false, err
+	}
+
+	return parseAcknowledged(jsonBody)
+}
+
+func (i *IndexImpl) PutComponentTemplate(ctx context.Context, name string, template models.ComponentTemplate) (bool, error) {
+	body, err := toJSONReader(template)
+	if err != nil {
+		return false, err
+	}
+
+	jsonBody, err := perform(ctx, i.es, esapi.ClusterPutComponentTemplateRequest{Name: name, Body: body}, "put component template")
+	if err != nil {
+		return false, err
+	}
+
+	return parseAcknowledged(jsonBody)
+}
+
+func (i *IndexImpl) GetComponentTemplate(ctx context.Context, name string) (*models.ComponentTemplate, error) {
+	jsonBody, err := perform(ctx, i.es, esapi.ClusterGetComponentTemplateRequest{Name: name}, "get component template")
+	if err != nil {
+		return nil, err
+	}
+
+	templates, err := models.UnmarshalComponentTemplatesResponse(jsonBody)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, template := range templates.ComponentTemplates {
+		if template.Name == name {
+			return &template.ComponentTemplate, nil
+		}
+	}
+
+	return nil, fmt.Errorf("component template %q missing from response", name)
+}
+
+func (i *IndexImpl) DeleteComponentTemplate(ctx context.Context, name string) (bool, error) {
+	jsonBody, err := perform(ctx, i.es, esapi.ClusterDeleteComponentTemplateRequest{Name: []string{name}}, "delete component template")
+	if err != nil {
+		return false, err
+	}
+
+	return parseAcknowledged(jsonBody)
+}
@@ -0,0 +1,163 @@
+package aristoteles
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/odysseia-greek/agora/aristoteles/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIndexTemplates(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("PutIndexTemplate", func(t *testing.T) {
+		testClient, err := NewMockClient("deleteIndex", 200)
+		assert.Nil(t, err)
+
+		priority := 200
+		acknowledged, err := testClient.Index().PutIndexTemplate(ctx, "tracing", models.IndexTemplate{
+			IndexPatterns: []string{"tracing-*"},
+			ComposedOf:    []string{"tracing-mappings"},
+			Priority:      &priority,
+		})
+		assert.Nil(t, err)
+		assert.True(t, acknowledged)
+	})
+
+	t.Run("GetIndexTemplate", func(t *testing.T) {
+		testClient, err := NewMockClient("getIndexTemplate", 200)
+		assert.Nil(t, err)
+
+		template, err := testClient.Index().GetIndexTemplate(ctx, "tracing")
+		assert.Nil(t, err)
+		assert.Equal(t, []string{"tracing-*"}, template.IndexPatterns)
+		assert.Equal(t, []string{"tracing-settings", "tracing-mappings"}, template.ComposedOf)
+		assert.Equal(t, 200, *template.Priority)
+		assert.NotNil(t, template.Template.Settings["index"])
+	})
+
+	t.Run("GetIndexTemplateMissing", func(t *testing.T) {
+		testClient, err := NewMockClient("getIndexTemplate", 200)
+		assert.Nil(t, err)
+
+		template, err := testClient.Index().GetIndexTemplate(ctx, "metrics")
+		assert.NotNil(t, err)
+		assert.Nil(t, template)
+	})
+
+	t.Run("DeleteIndexTemplateNotFound", func(t *testing.T) {
+		testClient, err := NewMockClient("deleteIndex404", 404)
+		assert.Nil(t, err)
+
+		acknowledged, err := testClient.Index().DeleteIndexTemplate(ctx, "tracing")
+		assert.NotNil(t, err)
+		assert.False(t, acknowledged)
+	})
+
+	t.Run("PutComponentTemplate", func(t *testing.T) {
+		testClient, err := NewMockClient("deleteIndex", 200)
+		assert.Nil(t, err)
+
+		acknowledged, err := testClient.Index().PutComponentTemplate(ctx, "tracing-mappings", models.ComponentTemplate{
+			Template: models.TemplateBody{Mappings: map[string]interface{}{"dynamic": false}},
+		})
+		assert.Nil(t, err)
+		assert.True(t, acknowledged)
+	})
+
+	t.Run("GetComponentTemplate", func(t *testing.T) {
+		testClient, err := NewMockClient("getComponentTemplate", 200)
+		assert.Nil(t, err)
+
+		template, err := testClient.Index().GetComponentTemplate(ctx, "tracing-mappings")
+		assert.Nil(t, err)
+		assert.Equal(t, int64(1), *template.Version)
+		assert.Contains(t, template.Template.Mappings, "properties")
+	})
+
+	t.Run("DeleteComponentTemplate", func(t *testing.T) {
+		testClient, err := NewMockClient("deleteIndex", 200)
+		assert.Nil(t, err)
+
+		acknowledged, err := testClient.Index().DeleteComponentTemplate(ctx, "tracing-mappings")
+		assert.Nil(t, err)
+		assert.True(t, acknowledged)
+	})
+
+	t.Run("MissingAcknowledged", func(t *testing.T) {
+		testClient, err := NewMockClient("getAlias", 200)
+		assert.Nil(t, err)
+
+		acknowledged, err := testClient.Index().DeleteComponentTemplate(ctx, "tracing-mappings")
+		assert.NotNil(t, err)
+		assert.False(t, acknowledged)
+	})
+}
+
+func TestAliases(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("ActionsMarshal", func(t *testing.T) {
+		body, err := json.Marshal(map[string]interface{}{"actions": []models.AliasAction{
+			models.RemoveAliasAction("dictionary-v1", "dictionary"),
+			models.AddAliasAction("dictionary-v2", "dictionary"),
+			models.RemoveIndexAction("dictionary-v0"),
+		}})
+		assert.Nil(t, err)
+		assert.JSONEq(t, `{"actions":[
+			{"remove":{"index":"dictionary-v1","alias":"dictionary"}},
+			{"add":{"index":"dictionary-v2","alias":"dictionary"}},
+			{"remove_index":{"index":"dictionary-v0"}}
+		]}`, string(body))
+	})
+
+	t.Run("Swap", func(t *testing.T) {
+		testClient, err := NewMockClient("deleteIndex", 200)
+		assert.Nil(t, err)
+
+		acknowledged, err := testClient.Index().SwapAlias(ctx, "dictionary", "dictionary-v1", "dictionary-v2")
+		assert.Nil(t, err)
+		assert.True(t, acknowledged)
+	})
+
+	t.Run("NoActions", func(t *testing.T) {
+		testClient, err := NewMockClient("deleteIndex", 200)
+		assert.Nil(t, err)
+
+		acknowledged, err := testClient.Index().UpdateAliases(ctx)
+		assert.NotNil(t, err)
+		assert.False(t, acknowledged)
+	})
+
+	t.Run("AddFailed", func(t *testing.T) {
+		testClient, err := NewMockClient("deleteIndex404", 404)
+		assert.Nil(t, err)
+
+		acknowledged, err := testClient.Index().AddAlias(ctx, "dictionary-v2", "dictionary")
+		assert.NotNil(t, err)
+		assert.False(t, acknowledged)
+	})
+
+	t.Run("Get", func(t *testing.T) {
+		testClient, err := NewMockClient("getAlias", 200)
+		assert.Nil(t, err)
+
+		indices, err := testClient.Index().GetAlias(ctx, "dictionary")
+		assert.Nil(t, err)
+		assert.Equal(t, []string{"dictionary-v1", "dictionary-v2"}, indices)
+	})
+
+	t.Run("Rollover", func(t *testing.T) {
+		testClient, err := NewMockClient("rollover", 200)
+		assert.Nil(t, err)
+
+		result, err := testClient.Index().Rollover(ctx, "tracing", models.RolloverConditions{MaxAge: "7d", MaxDocs: 1000})
+		assert.Nil(t, err)
+		assert.True(t, result.RolledOver)
+		assert.Equal(t, "tracing-000001", result.OldIndex)
+		assert.Equal(t, "tracing-000002", result.NewIndex)
+		assert.True(t, result.Conditions["[max_docs: 1000]"])
+	})
+}
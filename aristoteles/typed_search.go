@@ -0,0 +1,141 @@
+package aristoteles
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/odysseia-greek/agora/aristoteles/models"
+)
+
+type TypedResponse[T any] struct {
+	Took     int64
+	TimedOut bool
+	Shards   models.Shards
+	Total    models.Total
+	MaxScore float64
+	ScrollId string
+	Hits     []TypedHit[T]
+	// DecodeErrors holds the hits whose _source could not be decoded into T; they are
+	// left out of Hits so a single bad document does not fail the whole search.
+	DecodeErrors []HitDecodeError
+}
+
+type TypedHit[T any] struct {
+	Index     string
+	ID        string
+	Score     float64
+	Source    T
+	Highlight map[string][]string
+	Sort      []interface{}
+}
+
+type HitDecodeError struct {
+	Position int
+	Index    string
+	ID       string
+	Err      error
+}
+
+func (e HitDecodeError) Error() string {
+	return fmt.Sprintf("decoding hit %d (%s/%s): %v", e.Position, e.Index, e.ID, e.Err)
+}
+
+func (e HitDecodeError) Unwrap() error {
+	return e.Err
+}
+
+func (r *TypedResponse[T]) Sources() []T {
+	sources := make([]T, len(r.Hits))
+	for i, hit := range r.Hits {
+		sources[i] = hit.Source
+	}
+	return sources
+}
+
+func (r *TypedResponse[T]) DecodeErr() error {
+	errs := make([]error, len(r.DecodeErrors))
+	for i, err := range r.DecodeErrors {
+		errs[i] = err
+	}
+	return errors.Join(errs...)
+}
+
+type rawSearchResponse struct {
+	ScrollId string        `json:"_scroll_id,omitempty"`
+	Took     int64         `json:"took"`
+	TimedOut bool          `json:"timed_out"`
+	Shards   models.Shards `json:"_shards"`
+	Hits     struct {
+		Total    models.Total `json:"total"`
+		MaxScore *float64     `json:"max_score"`
+		Hits     []rawHit     `json:"hits"`
+	} `json:"hits"`
+}
+
+type rawHit struct {
+	Index     string              `json:"_index"`
+	ID        string              `json:"_id"`
+	Score     *float64            `json:"_score"`
+	Source    json.RawMessage     `json:"_source"`
+	Highlight map[string][]string `json:"highlight"`
+	Sort      []interface{}       `json:"sort"`
+}
+
+// Search runs query against index and decodes every hit's _source into T.
+func Search[T any](ctx context.Context, client Client, index string, query map[string]interface{}) (*TypedResponse[T], error) {
+	body, err := client.Query().MatchRawWithContext(ctx, index, query)
+	if err != nil {
+		return nil, err
+	}
+
+	return DecodeSearchResponse[T](body)
+}
+
+func DecodeSearchResponse[T any](body []byte) (*TypedResponse[T], error) {
+	var raw rawSearchResponse
+	if err := json.Unmarshal(body, &raw); err != nil {
+		return nil, err
+	}
+
+	response := &TypedResponse[T]{
+		Took:     raw.Took,
+		TimedOut: raw.TimedOut,
+		Shards:   raw.Shards,
+		Total:    raw.Hits.Total,
+		ScrollId: raw.ScrollId,
+		Hits:     make([]TypedHit[T], 0, len(raw.Hits.Hits)),
+	}
+	if raw.Hits.MaxScore != nil {
+		response.MaxScore = *raw.Hits.MaxScore
+	}
+
+	for position, hit := range raw.Hits.Hits {
+		typed := TypedHit[T]{
+			Index:     hit.Index,
+			ID:        hit.ID,
+			Highlight: hit.Highlight,
+			Sort:      hit.Sort,
+		}
+		if hit.Score != nil {
+			typed.Score = *hit.Score
+		}
+
+		if len(hit.Source) > 0 {
+			if err := json.Unmarshal(hit.Source, &typed.Source); err != nil {
+				response.DecodeErrors = append(response.DecodeErrors, HitDecodeError{
+					Position: position,
+					Index:    hit.Index,
+					ID:       hit.ID,
+					Err:      err,
+				})
+				continue
+			}
+		}
+
+		response.Hits = append(response.Hits, typed)
+	}
+
+	return response, nil
+}
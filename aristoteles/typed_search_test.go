@@ -0,0 +1,93 @@
+package aristoteles
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type testMeros struct {
+	Greek    string `json:"greek"`
+	English  string `json:"english"`
+	Original string `json:"original"`
+}
+
+type testSortedMeros struct {
+	Greek       string `json:"greek"`
+	Translation string `json:"translation"`
+	Chapter     int    `json:"chapter"`
+}
+
+func TestTypedSearch(t *testing.T) {
+	index := "dictionary"
+	query := NewSearch().Query(NewMatchQuery("greek", "λόγος")).Build()
+
+	t.Run("DecodesSources", func(t *testing.T) {
+		testClient, err := NewMockClient("match", 200)
+		assert.Nil(t, err)
+
+		sut, err := Search[testMeros](context.Background(), testClient, index, query)
+		assert.Nil(t, err)
+		assert.Equal(t, int64(1), sut.Total.Value)
+		assert.Equal(t, 1, len(sut.Hits))
+		assert.Equal(t, "war", sut.Hits[0].Source.English)
+		assert.Equal(t, "kql-K3wBQcJL3VaFORqk", sut.Hits[0].ID)
+		assert.Equal(t, 5.93497, sut.Hits[0].Score)
+		assert.Equal(t, []testMeros{sut.Hits[0].Source}, sut.Sources())
+		assert.Nil(t, sut.DecodeErr())
+	})
+
+	t.Run("KeepsSortValues", func(t *testing.T) {
+		testClient, err := NewMockClient("sorted", 200)
+		assert.Nil(t, err)
+
+		sut, err := Search[testSortedMeros](context.Background(), testClient, "nomina", query)
+		assert.Nil(t, err)
+		assert.Equal(t, 15, sut.Hits[0].Source.Chapter)
+		assert.Equal(t, []interface{}{float64(15)}, sut.Hits[0].Sort)
+		assert.Equal(t, float64(0), sut.Hits[0].Score)
+	})
+
+	t.Run("HighlightsAndPerHitDecodeErrors", func(t *testing.T) {
+		testClient, err := NewMockClient("highlight", 200)
+		assert.Nil(t, err)
+
+		sut, err := Search[testMeros](context.Background(), testClient, index, query)
+		assert.Nil(t, err)
+		assert.Equal(t, 1, len(sut.Hits))
+		assert.Equal(t, []string{"<em>λόγος</em> –ου, ὁ"}, sut.Hits[0].Highlight["greek"])
+
+		assert.Equal(t, 1, len(sut.DecodeErrors))
+		assert.Equal(t, 1, sut.DecodeErrors[0].Position)
+		assert.Equal(t, "bQ4rlnsB9QC3skjkOi-D", sut.DecodeErrors[0].ID)
+		assert.NotNil(t, sut.DecodeErr())
+	})
+
+	t.Run("DecodeIntoMap", func(t *testing.T) {
+		testClient, err := NewMockClient("highlight", 200)
+		assert.Nil(t, err)
+
+		sut, err := Search[map[string]interface{}](context.Background(), testClient, index, query)
+		assert.Nil(t, err)
+		assert.Equal(t, 2, len(sut.Hits))
+	})
+
+	t.Run("ElasticError", func(t *testing.T) {
+		testClient, err := NewMockClient("serviceDown", 502)
+		assert.Nil(t, err)
+
+		sut, err := Search[testMeros](context.Background(), testClient, index, query)
+		assert.NotNil(t, err)
+		assert.Nil(t, sut)
+	})
+
+	t.Run("Malformed", func(t *testing.T) {
+		testClient, err := NewMockClient("malformed", 200)
+		assert.Nil(t, err)
+
+		sut, err := Search[testMeros](context.Background(), testClient, index, query)
+		assert.NotNil(t, err)
+		assert.Nil(t, sut)
+	})
+}